@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"time"
+
+	"mesa-ads/internal/core/port"
+)
+
+// backfillStats rolls up the events recorded before the hourly stats
+// rollups were introduced. It runs as `mesa-ads backfill-stats [-from T]`
+// where T is an RFC3339 time; without -from every stored event is rolled
+// up. An interrupted backfill resumes where it stopped. It returns the
+// process exit code.
+func backfillStats(ctx context.Context, rollups port.RollupUseCase, logger *slog.Logger, args []string) int {
+	flags := flag.NewFlagSet("backfill-stats", flag.ContinueOnError)
+	fromStr := flags.String("from", "", "roll up events since this RFC3339 time instead of the oldest one")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	var from time.Time
+	if *fromStr != "" {
+		var err error
+		if from, err = time.Parse(time.RFC3339, *fromStr); err != nil {
+			logger.Error("invalid -from timestamp", slog.Any("error", err))
+			return 2
+		}
+	}
+
+	hours, err := rollups.Backfill(ctx, from)
+	if err != nil {
+		logger.Error("stats backfill error", slog.Any("error", err), slog.Int64("hours", hours))
+		return 1
+	}
+	logger.Info("stats backfill completed", slog.Int64("hours", hours))
+	return 0
+}
@@ -4,23 +4,42 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"mesa-ads/internal/adapter/cache"
+	"mesa-ads/internal/adapter/ctr"
+	"mesa-ads/internal/adapter/frequency"
+	"mesa-ads/internal/adapter/grpc"
 	"mesa-ads/internal/adapter/http"
+	"mesa-ads/internal/adapter/metrics"
+	"mesa-ads/internal/adapter/pacing"
 	"mesa-ads/internal/adapter/postgres"
+	"mesa-ads/internal/adapter/scheduler"
+	"mesa-ads/internal/adapter/token"
+	"mesa-ads/internal/adapter/tracing"
 	"mesa-ads/internal/adapter/usecase"
 	"mesa-ads/internal/config"
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
 	"mesa-ads/internal/db"
+	"mesa-ads/migrations"
 )
 
 // main is the entry point of the mesa-ads usecase. It loads configuration,
 // optionally runs database migrations, initializes the database pool and
-// repositories, then starts the HTTP server. On receiving a termination
-// signal it gracefully shuts down the server.
+// repositories, then starts the HTTP and gRPC servers. On receiving a
+// termination signal it gracefully shuts down both servers. Started as
+// `mesa-ads backfill-stats` it rolls up existing events and exits instead.
 func main() {
 	exitCode := 1
 	defer func() {
@@ -53,18 +72,39 @@ func main() {
 	}
 
 	// Optionally run migrations if configured. We use the Psql sub‑config.
+	// Serving on top of a schema the binary does not expect only produces
+	// failing requests, so a migration error is fatal.
 	if cfg.Psql.RunMigrations {
 		if err = db.Migrate(cfg.Psql.Addr.String()); err != nil {
 			logger.Error("migration error", slog.Any("error", err))
-		} else {
-			logger.Info("migrations applied successfully")
+			os.Exit(1)
 		}
+		logger.Info("migrations applied successfully")
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	// ctx outlives the termination signal: background jobs, the candidate
+	// cache and its listeners keep running while the instance drains and
+	// stop only once the servers are down.
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	tracerProvider, shutdownTracing, err := tracing.NewProvider(ctx, cfg.Tracing)
+	if err != nil {
+		logger.Error("tracing setup error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer func() {
+		// Export the remaining spans; ctx is cancelled by now.
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer flushCancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("tracing shutdown error", slog.Any("error", err))
+		}
+	}()
 
-	pool, err := db.NewPostgresPool(ctx, cfg.Psql)
+	pool, err := db.NewPostgresPool(ctx, cfg.Psql, db.WithTracer(tracing.NewQueryTracer(tracerProvider)))
 	if err != nil {
 		logger.Error("database connection error", slog.Any("error", err))
 		os.Exit(1)
@@ -79,10 +119,158 @@ func main() {
 		}
 	}
 
-	repo := postgres.NewAdRepository(pool)
-	svc := usecase.NewAdUseCase(repo)
+	rollups := usecase.NewRollupUseCase(postgres.NewRollupRepository(pool), cfg.Scheduler.RollupDelay)
+	if len(os.Args) > 1 && os.Args[1] == "backfill-stats" {
+		backfillCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		exitCode = backfillStats(backfillCtx, rollups, logger, os.Args[2:])
+		return
+	}
+
+	loc, err := cfg.Scheduler.Location()
+	if err != nil {
+		logger.Error("invalid scheduler timezone", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	healthChecks := []port.HealthChecker{
+		postgres.NewDatabaseCheck(pool),
+		postgres.NewMigrationCheck(pool, migrations.Version),
+	}
+	repo := postgres.NewAdRepository(pool, logger)
+	var adRepo port.AdRepository = repo
+	if cfg.Cache.Enabled {
+		candidates := cache.NewAdRepository(repo, repo, logger)
+		var changes <-chan struct{}
+		if cfg.Cache.Listen {
+			changes = postgres.ListenCandidateChanges(ctx, pool, logger)
+		}
+		go candidates.Run(ctx, cfg.Cache.RefreshInterval, changes)
+		if cfg.Cache.BudgetInterval > 0 {
+			go candidates.RunBudgets(ctx, cfg.Cache.BudgetInterval)
+		}
+		adRepo = candidates
+		healthChecks = append(healthChecks, cache.NewWarmCheck(candidates))
+	}
+	health := usecase.NewHealthUseCase(healthChecks...)
+	defaultCaps, err := domain.ParseFrequencyCaps(cfg.Ads.DefaultFrequencyCaps)
+	if err != nil {
+		logger.Error("invalid default frequency caps", slog.Any("error", err))
+		os.Exit(1)
+	}
+	var frequencyStore port.FrequencyStore
+	switch cfg.Ads.FrequencyStore {
+	case "postgres":
+		frequencyStore = postgres.NewFrequencyStore(pool)
+	case "memory":
+		store := frequency.NewMemoryStore()
+		go store.Run(ctx, time.Hour)
+		frequencyStore = store
+	default:
+		logger.Error("unknown frequency store", slog.String("store", cfg.Ads.FrequencyStore))
+		os.Exit(1)
+	}
+
+	reserves, err := domain.ParseReservePrices(cfg.Ads.ReservePrices)
+	if err != nil {
+		logger.Error("invalid reserve prices", slog.Any("error", err))
+		os.Exit(1)
+	}
+	tokenKeys, err := token.ParseKeys(cfg.Token.Keys)
+	if err != nil {
+		logger.Error("invalid token keys", slog.Any("error", err))
+		os.Exit(1)
+	}
+	signer := token.NewSigner(tokenKeys, cfg.Token.TTL, cfg.Token.RotatedAt, cfg.Token.RotationGrace, time.Now)
+
+	estimator := ctr.NewEstimator(postgres.NewCTRRepository(pool), logger, time.Now,
+		cfg.Ads.CTRPrior, cfg.Ads.CTRPriorWeight, cfg.Ads.CTRWindow)
+	go estimator.Run(ctx, cfg.Ads.CTRRefreshInterval)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewPoolCollector(func() metrics.PoolStats { return pool.Stat() }),
+		metrics.NewInvalidTargetingCounter(repo.InvalidTargetingCount),
+	)
+	adMetrics := metrics.New(registry, metrics.WithSpendCampaigns(cfg.Metrics.SpendCampaigns))
+
+	tracedRepo := tracing.NewAdRepository(adRepo, tracerProvider)
+	svc := usecase.NewAdUseCase(metrics.NewAdRepository(tracedRepo, adMetrics),
+		usecase.WithPacer(pacing.NewPacer(loc, time.Now, cfg.Ads.PacingTolerance)),
+		usecase.WithFrequencyCapper(frequency.NewCapper(frequencyStore, defaultCaps, logger, time.Now)),
+		usecase.WithCTREstimator(estimator),
+		usecase.WithReservePrices(reserves),
+		usecase.WithReservationTTL(cfg.Ads.ReservationTTL),
+		usecase.WithTokenSigner(signer),
+	)
+	campaignRepo := postgres.NewCampaignRepository(pool)
+	campaigns := usecase.NewCampaignUseCase(campaignRepo)
+	creatives := usecase.NewCreativeUseCase(postgres.NewCreativeRepository(pool), campaignRepo)
 
-	handler := httpadapter.NewHandler(svc, logger)
+	sched := scheduler.New(logger)
+	if cfg.Scheduler.Enabled {
+		budgets := usecase.NewBudgetUseCase(postgres.NewBudgetRepository(pool), loc)
+		sched.Add(scheduler.Job{
+			Name:     "daily-budget-reset",
+			Interval: cfg.Scheduler.BudgetResetInterval,
+			Run: func(ctx context.Context) error {
+				reset, err := budgets.ResetDailyBudgets(ctx, time.Now())
+				if err == nil && reset != nil {
+					logger.Info("daily budgets reset",
+						slog.Time("date", reset.Date), slog.Int64("campaigns", reset.Campaigns))
+				}
+				return err
+			},
+		})
+		sched.Add(scheduler.Job{
+			Name:     "campaign-lifecycle",
+			Interval: cfg.Scheduler.LifecycleInterval,
+			Run: func(ctx context.Context) error {
+				moved, err := campaigns.ApplyAutomaticTransitions(ctx, time.Now())
+				if moved > 0 {
+					logger.Info("campaign statuses updated", slog.Int("campaigns", moved))
+				}
+				return err
+			},
+		})
+		sched.Add(scheduler.Job{
+			Name:     "stats-rollup",
+			Interval: cfg.Scheduler.RollupInterval,
+			Run: func(ctx context.Context) error {
+				hours, err := rollups.RollUp(ctx, time.Now())
+				if hours > 0 {
+					logger.Info("stats rolled up", slog.Int64("hours", hours))
+				}
+				return err
+			},
+		})
+		sched.Add(scheduler.Job{
+			Name:     "reservation-release",
+			Interval: cfg.Scheduler.ReservationReleaseInterval,
+			Run: func(ctx context.Context) error {
+				released, err := svc.ReleaseExpiredReservations(ctx, time.Now())
+				if released > 0 {
+					logger.Info("expired reservations released", slog.Int64("reservations", released))
+				}
+				return err
+			},
+		})
+	}
+	sched.Start(ctx)
+
+	adService := metrics.NewAdUseCase(tracing.NewAdUseCase(svc, tracerProvider), adMetrics)
+	handler := httpadapter.NewHandler(adService, logger,
+		httpadapter.WithMetrics(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})),
+		httpadapter.WithTracing(tracerProvider),
+		httpadapter.WithHealth(health),
+		httpadapter.WithCampaigns(campaigns),
+		httpadapter.WithCreatives(creatives),
+		httpadapter.WithOpenRTB(cfg.OpenRTB.Currency, cfg.OpenRTB.MinorUnits),
+		httpadapter.WithExport(usecase.NewExportUseCase(postgres.NewExportRepository(pool, cfg.Export.BatchSize)),
+			cfg.Export.APIKeys),
+	)
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.HTTP.Port),
 		Handler: handler.Router(),
@@ -95,16 +283,60 @@ func main() {
 		}
 	}()
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	var grpcSrv *grpc.Server
+	if cfg.GRPC.Enabled {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			logger.Error("grpc listen error", slog.Any("error", err))
+			os.Exit(1)
+		}
+		grpcSrv = grpc.NewServer()
+		grpcadapter.NewServer(adService, logger).Register(grpcSrv)
+		go func() {
+			logger.Info("grpc server listening", slog.Int("port", int(cfg.GRPC.Port)))
+			if err := grpcSrv.Serve(lis); err != nil {
+				logger.Error("grpc server error", slog.Any("error", err))
+			}
+		}()
+	}
+
 	value := <-quit
 	exitCode = 128 + int(value.(syscall.Signal))
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 5*time.Second)
+	// Fail readiness first and keep serving while load balancers notice,
+	// so no new requests arrive at servers that are about to stop.
+	health.Drain()
+	logger.Info("draining before shutdown", slog.Duration("delay", cfg.HTTP.DrainDelay))
+	time.Sleep(cfg.HTTP.DrainDelay)
+
+	// The timeout starts after the drain delay.
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdownCancel()
+	// Both servers drain concurrently within the shared timeout.
+	grpcStopped := make(chan struct{})
+	if grpcSrv != nil {
+		go func() {
+			grpcSrv.GracefulStop()
+			close(grpcStopped)
+		}()
+	}
 	if err = srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("server shutdown error", slog.Any("error", err))
 	} else {
 		logger.Info("server gracefully stopped")
 	}
+	if grpcSrv != nil {
+		select {
+		case <-grpcStopped:
+			logger.Info("grpc server gracefully stopped")
+		case <-shutdownCtx.Done():
+			// GracefulStop waits for pending RPCs without a deadline.
+			grpcSrv.Stop()
+			logger.Error("grpc server shutdown error", slog.Any("error", shutdownCtx.Err()))
+		}
+	}
+
+	// Stop background jobs only now, after the last request is served.
+	cancel()
+	sched.Wait()
 }
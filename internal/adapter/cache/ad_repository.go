@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// AdRepository decorates a port.AdRepository with an in-memory snapshot of
+// the active candidates, so eligibility lookups do not query the database.
+// Every other method, including budget deductions, goes to the wrapped
+// repository, which stays authoritative for budgets: a campaign that fails
+// a deduction is hidden until the next refresh. Deductions committed by any
+// instance are polled into the snapshot with SyncBudgets.
+type AdRepository struct {
+	port.AdRepository
+
+	source port.CandidateRepository
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu sync.RWMutex
+	// candidates is nil until the first successful refresh.
+	candidates []port.CreativeCandidate
+	// index is the targeting index over candidates.
+	index *index
+	// byCampaign maps campaign IDs to their positions in candidates.
+	byCampaign map[int64][]int
+	// exhausted holds campaigns that ran out of budget since the snapshot
+	// was taken.
+	exhausted map[int64]struct{}
+	// refreshedAt is when the current snapshot was loaded.
+	refreshedAt time.Time
+}
+
+// NewAdRepository wraps repo with a candidate cache filled from source.
+// The cache is cold until Refresh succeeds; lookups on a cold cache are
+// delegated to repo.
+func NewAdRepository(repo port.AdRepository, source port.CandidateRepository, logger *slog.Logger) *AdRepository {
+	return &AdRepository{
+		AdRepository: repo,
+		source:       source,
+		logger:       logger,
+		now:          time.Now,
+		exhausted:    make(map[int64]struct{}),
+	}
+}
+
+// Refresh replaces the snapshot with the current candidates of source.
+func (r *AdRepository) Refresh(ctx context.Context) error {
+	candidates, err := r.source.ListActiveCandidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	idx := newIndex(candidates)
+	byCampaign := make(map[int64][]int)
+	for pos, c := range candidates {
+		byCampaign[c.Campaign.ID] = append(byCampaign[c.Campaign.ID], pos)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.candidates = candidates
+	r.index = idx
+	r.byCampaign = byCampaign
+	r.exhausted = make(map[int64]struct{})
+	r.refreshedAt = r.now()
+	return nil
+}
+
+// SyncBudgets applies the remaining budgets of source to the snapshot, so
+// pacing sees the spend of every instance between refreshes.
+func (r *AdRepository) SyncBudgets(ctx context.Context) error {
+	budgets, err := r.source.ListCampaignBudgets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range budgets {
+		r.ApplyBudget(u)
+	}
+	return nil
+}
+
+// RunBudgets calls SyncBudgets every interval until ctx is cancelled.
+func (r *AdRepository) RunBudgets(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.SyncBudgets(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("candidate budget sync failed", slog.Any("error", err))
+		}
+	}
+}
+
+// ApplyBudget lowers the cached remaining budgets of a campaign to the
+// values left by committed deductions, so pacing sees the live spend
+// between refreshes. Budgets only grow through edits and resets, which
+// reload the snapshot, so larger values are out of date and ignored.
+func (r *AdRepository) ApplyBudget(u port.BudgetUpdate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, pos := range r.byCampaign[u.CampaignID] {
+		c := &r.candidates[pos].Campaign
+		c.RemainingDailyBudget = min(c.RemainingDailyBudget, u.RemainingDailyBudget)
+		c.RemainingTotalBudget = min(c.RemainingTotalBudget, u.RemainingTotalBudget)
+		if c.RemainingDailyBudget <= 0 || c.RemainingTotalBudget <= 0 {
+			r.exhausted[u.CampaignID] = struct{}{}
+		}
+	}
+}
+
+// Warm reports whether the cache holds a snapshot.
+func (r *AdRepository) Warm() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.candidates != nil
+}
+
+// RefreshedAt returns when the current snapshot was loaded, or the zero
+// time for a cold cache.
+func (r *AdRepository) RefreshedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.refreshedAt
+}
+
+// Run refreshes the cache immediately, then every interval and whenever
+// changes delivers a value, until ctx is cancelled. A nil changes channel
+// disables change notifications.
+func (r *AdRepository) Run(ctx context.Context, interval time.Duration, changes <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("candidate cache refresh failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+			}
+		}
+	}
+}
+
+// GetEligibleCreatives returns the cached candidates matching the user
+// context.
+func (r *AdRepository) GetEligibleCreatives(
+	ctx context.Context,
+	user domain.UserContext,
+) ([]port.CreativeCandidate, error) {
+	now := r.now()
+
+	r.mu.RLock()
+	if r.candidates == nil {
+		r.mu.RUnlock()
+		return r.AdRepository.GetEligibleCreatives(ctx, user)
+	}
+	matched := make([]port.CreativeCandidate, 0)
+	for _, pos := range r.index.lookup(user) {
+		c := r.candidates[pos]
+		if _, ok := r.exhausted[c.Campaign.ID]; ok {
+			continue
+		}
+		if c.Campaign.Running(now) {
+			matched = append(matched, c)
+		}
+	}
+	r.mu.RUnlock()
+	return matched, nil
+}
+
+// ReserveImpression deducts the CPM budget for a reservation in the
+// wrapped repository and hides the campaign once it runs out of budget.
+func (r *AdRepository) ReserveImpression(
+	ctx context.Context,
+	imp domain.Impression,
+	cpmPrice int64,
+	expiresAt time.Time,
+) error {
+	err := r.AdRepository.ReserveImpression(ctx, imp, cpmPrice, expiresAt)
+	r.checkBudget(imp.CampaignID, err)
+	return err
+}
+
+// CreateClickAndDeductBudget deducts the CPC budget in the wrapped
+// repository and hides the campaign once it runs out of budget.
+func (r *AdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcPrice int64) error {
+	err := r.AdRepository.CreateClickAndDeductBudget(ctx, click, cpcPrice)
+	r.checkBudget(click.CampaignID, err)
+	return err
+}
+
+func (r *AdRepository) checkBudget(campaignID int64, err error) {
+	if !errors.Is(err, port.ErrInsufficientBudget) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exhausted[campaignID] = struct{}{}
+}
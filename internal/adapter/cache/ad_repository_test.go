@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+func candidate(campaignID, creativeID int64, now time.Time, tgt domain.Targeting) port.CreativeCandidate {
+	tgt.Normalize()
+	return port.CreativeCandidate{
+		Creative: domain.Creative{ID: creativeID, CampaignID: campaignID},
+		Campaign: domain.Campaign{
+			ID:        campaignID,
+			StartDate: now.Add(-time.Hour),
+			EndDate:   now.Add(time.Hour),
+			CPMBid:    1000,
+		},
+		Target: tgt,
+	}
+}
+
+// TestCachedLookup ensures warm lookups use the snapshot instead of the wrapped repository.
+func TestCachedLookup(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	source := mocks.NewMockCandidateRepository(t)
+
+	now := time.Now()
+	source.EXPECT().ListActiveCandidates(mock.Anything).Return([]port.CreativeCandidate{
+		candidate(1, 10, now, domain.Targeting{Geos: []string{"RU"}}),
+		candidate(2, 20, now, domain.Targeting{Geos: []string{"AM"}}),
+	}, nil).Once()
+
+	r := NewAdRepository(repo, source, slog.Default())
+	if err := r.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+
+	for range 3 {
+		got, err := r.GetEligibleCreatives(context.Background(), domain.UserContext{UserID: "u1", Geo: "AM"})
+		if err != nil {
+			t.Fatalf("GetEligibleCreatives error: %v", err)
+		}
+		if len(got) != 1 || got[0].Creative.ID != 20 {
+			t.Fatalf("unexpected candidates: %+v", got)
+		}
+	}
+}
+
+// TestColdCacheFallsBack ensures lookups before the first refresh reach the wrapped repository.
+func TestColdCacheFallsBack(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	source := mocks.NewMockCandidateRepository(t)
+
+	user := domain.UserContext{UserID: "u1"}
+	repo.EXPECT().GetEligibleCreatives(mock.Anything, user).Return([]port.CreativeCandidate{}, nil).Once()
+
+	r := NewAdRepository(repo, source, slog.Default())
+	if r.Warm() {
+		t.Fatalf("expected cold cache")
+	}
+	if _, err := r.GetEligibleCreatives(context.Background(), user); err != nil {
+		t.Fatalf("GetEligibleCreatives error: %v", err)
+	}
+}
+
+// TestExhaustedCampaignHidden ensures a failed deduction hides the campaign until the next refresh.
+func TestExhaustedCampaignHidden(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	source := mocks.NewMockCandidateRepository(t)
+
+	now := time.Now()
+	snapshot := []port.CreativeCandidate{
+		candidate(1, 10, now, domain.Targeting{}),
+		candidate(2, 20, now, domain.Targeting{}),
+	}
+	source.EXPECT().ListActiveCandidates(mock.Anything).Return(snapshot, nil).Twice()
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(1000), mock.Anything).
+		Return(port.ErrInsufficientBudget)
+
+	r := NewAdRepository(repo, source, slog.Default())
+	ctx := context.Background()
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	_ = r.ReserveImpression(ctx, domain.Impression{CampaignID: 1}, 1000, now.Add(time.Minute))
+
+	got, _ := r.GetEligibleCreatives(ctx, domain.UserContext{})
+	if len(got) != 1 || got[0].Campaign.ID != 2 {
+		t.Fatalf("expected only campaign 2, got %+v", got)
+	}
+
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if got, _ = r.GetEligibleCreatives(ctx, domain.UserContext{}); len(got) != 2 {
+		t.Fatalf("expected both campaigns after refresh, got %+v", got)
+	}
+}
+
+// TestFlightDates ensures cached campaigns only serve within their flight dates.
+func TestFlightDates(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	source := mocks.NewMockCandidateRepository(t)
+
+	now := time.Now()
+	future := candidate(1, 10, now, domain.Targeting{})
+	future.Campaign.StartDate = now.Add(time.Minute)
+	source.EXPECT().ListActiveCandidates(mock.Anything).Return([]port.CreativeCandidate{future}, nil)
+
+	r := NewAdRepository(repo, source, slog.Default())
+	if err := r.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	got, err := r.GetEligibleCreatives(context.Background(), domain.UserContext{})
+	if err != nil || len(got) != 0 {
+		t.Fatalf("expected no candidates, got %+v (%v)", got, err)
+	}
+
+	r.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if got, _ = r.GetEligibleCreatives(context.Background(), domain.UserContext{}); len(got) != 1 {
+		t.Fatalf("expected campaign to start serving, got %+v", got)
+	}
+}
+
+// TestApplyBudget ensures deductions lower the cached budgets, stale budgets
+// are ignored and spent campaigns are hidden.
+func TestApplyBudget(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	source := mocks.NewMockCandidateRepository(t)
+
+	now := time.Now()
+	c := candidate(1, 10, now, domain.Targeting{})
+	c.Campaign.DailyBudget, c.Campaign.RemainingDailyBudget = 10000, 8000
+	c.Campaign.TotalBudget, c.Campaign.RemainingTotalBudget = 50000, 40000
+	source.EXPECT().ListActiveCandidates(mock.Anything).Return([]port.CreativeCandidate{c}, nil)
+
+	r := NewAdRepository(repo, source, slog.Default())
+	ctx := context.Background()
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+
+	r.ApplyBudget(port.BudgetUpdate{CampaignID: 1, RemainingDailyBudget: 6000, RemainingTotalBudget: 38000})
+	// budgets read before the ones above arrive late
+	r.ApplyBudget(port.BudgetUpdate{CampaignID: 1, RemainingDailyBudget: 7000, RemainingTotalBudget: 39000})
+	r.ApplyBudget(port.BudgetUpdate{CampaignID: 2, RemainingDailyBudget: 0, RemainingTotalBudget: 0})
+
+	got, _ := r.GetEligibleCreatives(ctx, domain.UserContext{})
+	if len(got) != 1 {
+		t.Fatalf("expected campaign 1, got %+v", got)
+	}
+	if b := got[0].Campaign; b.RemainingDailyBudget != 6000 || b.RemainingTotalBudget != 38000 {
+		t.Fatalf("remaining budgets = %d/%d, want 6000/38000", b.RemainingDailyBudget, b.RemainingTotalBudget)
+	}
+
+	r.ApplyBudget(port.BudgetUpdate{CampaignID: 1, RemainingDailyBudget: 0, RemainingTotalBudget: 32000})
+	if got, _ = r.GetEligibleCreatives(ctx, domain.UserContext{}); len(got) != 0 {
+		t.Fatalf("expected spent campaign hidden, got %+v", got)
+	}
+}
+
+// TestSyncBudgets ensures polled budgets of other instances reach the
+// snapshot.
+func TestSyncBudgets(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	source := mocks.NewMockCandidateRepository(t)
+
+	now := time.Now()
+	c := candidate(1, 10, now, domain.Targeting{})
+	c.Campaign.RemainingDailyBudget, c.Campaign.RemainingTotalBudget = 8000, 40000
+	source.EXPECT().ListActiveCandidates(mock.Anything).Return([]port.CreativeCandidate{c}, nil)
+	source.EXPECT().ListCampaignBudgets(mock.Anything).Return([]port.BudgetUpdate{
+		{CampaignID: 1, RemainingDailyBudget: 5000, RemainingTotalBudget: 37000},
+	}, nil)
+
+	r := NewAdRepository(repo, source, slog.Default())
+	ctx := context.Background()
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if err := r.SyncBudgets(ctx); err != nil {
+		t.Fatalf("SyncBudgets error: %v", err)
+	}
+
+	got, _ := r.GetEligibleCreatives(ctx, domain.UserContext{})
+	if len(got) != 1 || got[0].Campaign.RemainingDailyBudget != 5000 || got[0].Campaign.RemainingTotalBudget != 37000 {
+		t.Fatalf("expected synced budgets, got %+v", got)
+	}
+}
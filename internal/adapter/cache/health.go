@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+	"errors"
+)
+
+// errCold is reported by WarmCheck until the first snapshot is loaded.
+var errCold = errors.New("candidate cache not loaded yet")
+
+// WarmCheck implements port.HealthChecker for the candidate cache. It
+// fails until the cache holds a snapshot; lookups on a cold cache still
+// work but go to the database.
+type WarmCheck struct {
+	cache *AdRepository
+}
+
+// NewWarmCheck returns a readiness check of cache.
+func NewWarmCheck(cache *AdRepository) *WarmCheck {
+	return &WarmCheck{cache: cache}
+}
+
+// Name implements port.HealthChecker.
+func (c *WarmCheck) Name() string { return "candidate_cache" }
+
+// Check implements port.HealthChecker.
+func (c *WarmCheck) Check(context.Context) error {
+	if !c.cache.Warm() {
+		return errCold
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestWarmCheck ensures readiness fails until the first refresh succeeds.
+func TestWarmCheck(t *testing.T) {
+	source := mocks.NewMockCandidateRepository(t)
+	source.EXPECT().ListActiveCandidates(mock.Anything).Return([]port.CreativeCandidate{}, nil).Once()
+
+	r := NewAdRepository(mocks.NewMockAdRepository(t), source, slog.Default())
+	check := NewWarmCheck(r)
+	if err := check.Check(context.Background()); err == nil {
+		t.Fatalf("expected a cold cache to fail the check")
+	}
+	if err := r.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if err := check.Check(context.Background()); err != nil {
+		t.Fatalf("Check error after refresh: %v", err)
+	}
+}
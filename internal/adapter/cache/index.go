@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"math/bits"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// bitmap is a set of candidate positions, one bit per candidate.
+type bitmap []uint64
+
+func newBitmap(n int) bitmap {
+	return make(bitmap, (n+63)/64)
+}
+
+func (b bitmap) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+func (b bitmap) or(o bitmap) {
+	for i := range b {
+		b[i] |= o[i]
+	}
+}
+
+func (b bitmap) and(o bitmap) {
+	for i := range b {
+		b[i] &= o[i]
+	}
+}
+
+// positions appends the positions of the set bits to dst in ascending
+// order.
+func (b bitmap) positions(dst []int) []int {
+	for i, word := range b {
+		for word != 0 {
+			dst = append(dst, i*64+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return dst
+}
+
+// dimension is the inverted index of one targeting list.
+type dimension struct {
+	// any holds candidates that do not restrict the dimension.
+	any bitmap
+	// postings maps a value to the candidates listing it.
+	postings map[string]bitmap
+}
+
+func newDimension(n int) dimension {
+	return dimension{any: newBitmap(n), postings: make(map[string]bitmap)}
+}
+
+func (d *dimension) add(pos int, values []string) {
+	if len(values) == 0 {
+		d.any.set(pos)
+		return
+	}
+	for _, v := range values {
+		p, ok := d.postings[v]
+		if !ok {
+			p = make(bitmap, len(d.any))
+			d.postings[v] = p
+		}
+		p.set(pos)
+	}
+}
+
+// match stores in dst the candidates accepting at least one of values.
+func (d *dimension) match(dst bitmap, values ...string) {
+	copy(dst, d.any)
+	for _, v := range values {
+		if p, ok := d.postings[v]; ok {
+			dst.or(p)
+		}
+	}
+}
+
+// index is an inverted targeting index over a fixed list of candidates. It
+// answers the same question as domain.Targeting.Matches for all candidates
+// at once by intersecting per-dimension posting lists. Candidates must be
+// normalised; an index is read-only once built.
+type index struct {
+	size       int
+	languages  dimension
+	geos       dimension
+	categories dimension
+	placements dimension
+	interests  dimension
+}
+
+func newIndex(candidates []port.CreativeCandidate) *index {
+	n := len(candidates)
+	idx := &index{
+		size:       n,
+		languages:  newDimension(n),
+		geos:       newDimension(n),
+		categories: newDimension(n),
+		placements: newDimension(n),
+		interests:  newDimension(n),
+	}
+	for pos, c := range candidates {
+		idx.languages.add(pos, c.Target.Languages)
+		idx.geos.add(pos, c.Target.Geos)
+		idx.categories.add(pos, c.Target.Categories)
+		idx.placements.add(pos, c.Target.Placements)
+		idx.interests.add(pos, c.Target.Interests)
+	}
+	return idx
+}
+
+// lookup returns the positions of the candidates whose targeting matches
+// the normalised user context, in ascending order.
+func (idx *index) lookup(user domain.UserContext) []int {
+	result := newBitmap(idx.size)
+	scratch := newBitmap(idx.size)
+
+	idx.languages.match(result, user.Language)
+	idx.geos.match(scratch, user.Geo)
+	result.and(scratch)
+	idx.categories.match(scratch, user.Category)
+	result.and(scratch)
+	idx.placements.match(scratch, user.Placement)
+	result.and(scratch)
+	idx.interests.match(scratch, user.Interests...)
+	result.and(scratch)
+
+	return result.positions(nil)
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// TestIndexLookup ensures the index keeps the Targeting.Matches semantics.
+func TestIndexLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		tgt  domain.Targeting
+		user domain.UserContext
+		want bool
+	}{
+		{"empty targeting matches all", domain.Targeting{}, domain.UserContext{Geo: "RU"}, true},
+		{"empty targeting matches empty user", domain.Targeting{}, domain.UserContext{}, true},
+		{"language match", domain.Targeting{Languages: []string{"en", "ru"}}, domain.UserContext{Language: "ru"}, true},
+		{"language mismatch", domain.Targeting{Languages: []string{"en"}}, domain.UserContext{Language: "ru"}, false},
+		{"language required", domain.Targeting{Languages: []string{"en"}}, domain.UserContext{}, false},
+		{"geo match", domain.Targeting{Geos: []string{"AM"}}, domain.UserContext{Geo: "AM"}, true},
+		{"geo mismatch", domain.Targeting{Geos: []string{"AM"}}, domain.UserContext{Geo: "RU"}, false},
+		{"category match", domain.Targeting{Categories: []string{"sport"}}, domain.UserContext{Category: "sport"}, true},
+		{"category mismatch", domain.Targeting{Categories: []string{"sport"}}, domain.UserContext{Category: "news"}, false},
+		{
+			"placement match",
+			domain.Targeting{Placements: []string{domain.PlacementPreRoll}},
+			domain.UserContext{Placement: domain.PlacementPreRoll},
+			true,
+		},
+		{
+			"placement mismatch",
+			domain.Targeting{Placements: []string{domain.PlacementPreRoll}},
+			domain.UserContext{Placement: domain.PlacementMidRoll},
+			false,
+		},
+		{
+			"interests any-of",
+			domain.Targeting{Interests: []string{"cars", "gaming"}},
+			domain.UserContext{Interests: []string{"music", "gaming"}},
+			true,
+		},
+		{
+			"interests disjoint",
+			domain.Targeting{Interests: []string{"cars"}},
+			domain.UserContext{Interests: []string{"music"}},
+			false,
+		},
+		{"interests required", domain.Targeting{Interests: []string{"cars"}}, domain.UserContext{}, false},
+		{
+			"all dimensions",
+			domain.Targeting{
+				Languages:  []string{"en"},
+				Geos:       []string{"US"},
+				Categories: []string{"sport"},
+				Interests:  []string{"cars"},
+				Placements: []string{domain.PlacementPostRoll},
+			},
+			domain.UserContext{
+				Language:  "en",
+				Geo:       "US",
+				Category:  "sport",
+				Interests: []string{"cars"},
+				Placement: domain.PlacementPostRoll,
+			},
+			true,
+		},
+		{
+			"one dimension off",
+			domain.Targeting{Languages: []string{"en"}, Geos: []string{"US"}},
+			domain.UserContext{Language: "en", Geo: "CA"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tgt.Normalize()
+			if got := tt.tgt.Matches(tt.user); got != tt.want {
+				t.Fatalf("Matches = %v, want %v", got, tt.want)
+			}
+			got := newIndex([]port.CreativeCandidate{{Target: tt.tgt}}).lookup(tt.user)
+			if (len(got) == 1) != tt.want {
+				t.Fatalf("lookup = %v, want match %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIndexAgreesWithScan ensures the index returns exactly the candidates a linear scan matches.
+func TestIndexAgreesWithScan(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	candidates := randomCandidates(rnd, 5000)
+	idx := newIndex(candidates)
+
+	for range 500 {
+		user := randomUser(rnd)
+		if got, want := idx.lookup(user), scan(candidates, user); !slices.Equal(got, want) {
+			t.Fatalf("user %+v: index %v, scan %v", user, got, want)
+		}
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 50_000} {
+		rnd := rand.New(rand.NewPCG(1, 2))
+		candidates := randomCandidates(rnd, n)
+		idx := newIndex(candidates)
+		users := make([]domain.UserContext, 256)
+		for i := range users {
+			users[i] = randomUser(rnd)
+		}
+
+		b.Run(fmt.Sprintf("scan/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = scan(candidates, users[i%len(users)])
+			}
+		})
+		b.Run(fmt.Sprintf("index/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = idx.lookup(users[i%len(users)])
+			}
+		})
+	}
+}
+
+// scan is the linear matching loop the index replaces.
+func scan(candidates []port.CreativeCandidate, user domain.UserContext) []int {
+	var out []int
+	for i := range candidates {
+		if candidates[i].Target.Matches(user) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+var (
+	testLanguages  = []string{"en", "ru", "hy", "de", "fr", "es"}
+	testGeos       = []string{"US", "RU", "AM", "DE", "FR", "ES", "GB", "KZ"}
+	testCategories = []string{"sport", "news", "music", "movies", "kids"}
+	testInterests  = []string{"cars", "gaming", "travel", "food", "tech", "fashion", "fitness", "finance"}
+	testPlacements = []string{domain.PlacementPreRoll, domain.PlacementMidRoll, domain.PlacementPostRoll}
+)
+
+// pick returns up to max distinct values; half of the time it returns none
+// so that match-all lists are common.
+func pick(rnd *rand.Rand, values []string, max int) []string {
+	if rnd.IntN(2) == 0 {
+		return nil
+	}
+	out := make([]string, 0, max)
+	for range 1 + rnd.IntN(max) {
+		out = append(out, values[rnd.IntN(len(values))])
+	}
+	return out
+}
+
+func randomCandidates(rnd *rand.Rand, n int) []port.CreativeCandidate {
+	candidates := make([]port.CreativeCandidate, n)
+	for i := range candidates {
+		tgt := domain.Targeting{
+			Languages:  pick(rnd, testLanguages, 2),
+			Geos:       pick(rnd, testGeos, 3),
+			Categories: pick(rnd, testCategories, 2),
+			Interests:  pick(rnd, testInterests, 3),
+			Placements: pick(rnd, testPlacements, 2),
+		}
+		tgt.Normalize()
+		candidates[i] = port.CreativeCandidate{
+			Creative: domain.Creative{ID: int64(i)},
+			Campaign: domain.Campaign{ID: int64(i)},
+			Target:   tgt,
+		}
+	}
+	return candidates
+}
+
+func randomUser(rnd *rand.Rand) domain.UserContext {
+	return domain.UserContext{
+		Language:  testLanguages[rnd.IntN(len(testLanguages))],
+		Geo:       testGeos[rnd.IntN(len(testGeos))],
+		Category:  testCategories[rnd.IntN(len(testCategories))],
+		Interests: pick(rnd, testInterests, 3),
+		Placement: testPlacements[rnd.IntN(len(testPlacements))],
+	}
+}
@@ -0,0 +1,155 @@
+package ctr
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"mesa-ads/internal/core/port"
+)
+
+// Estimator implements port.CTREstimator with smoothed historical CTRs.
+// Estimates are blended hierarchically: the global CTR is pulled towards
+// the configured prior, a campaign towards the global CTR and a creative
+// towards its campaign, each as if weight impressions had been observed at
+// the parent rate. New creatives thus start at their campaign's CTR and
+// move to their own as impressions accumulate.
+type Estimator struct {
+	repo   port.CTRRepository
+	logger *slog.Logger
+	now    func() time.Time
+	prior  float64
+	weight float64
+	window time.Duration
+
+	mu        sync.RWMutex
+	global    float64
+	campaigns map[int64]float64
+	creatives map[int64]float64
+}
+
+// NewEstimator creates an estimator reading events of the last window
+// from repo. prior is the CTR assumed without data, e.g. 0.01, and weight
+// the number of impressions the parent estimate is worth. Until the first
+// Refresh every estimate equals prior.
+func NewEstimator(
+	repo port.CTRRepository,
+	logger *slog.Logger,
+	now func() time.Time,
+	prior, weight float64,
+	window time.Duration,
+) *Estimator {
+	return &Estimator{
+		repo:      repo,
+		logger:    logger,
+		now:       now,
+		prior:     prior,
+		weight:    weight,
+		window:    window,
+		global:    prior,
+		campaigns: make(map[int64]float64),
+		creatives: make(map[int64]float64),
+	}
+}
+
+// Refresh recomputes the estimates from the events of the last window.
+func (e *Estimator) Refresh(ctx context.Context) error {
+	counts, err := e.repo.ListEventCounts(ctx, e.now().Add(-e.window))
+	if err != nil {
+		return err
+	}
+
+	type total struct{ impressions, clicks int64 }
+	var (
+		all       total
+		campaigns = make(map[int64]total)
+	)
+	for _, c := range counts {
+		all.impressions += c.Impressions
+		all.clicks += c.Clicks
+		t := campaigns[c.CampaignID]
+		t.impressions += c.Impressions
+		t.clicks += c.Clicks
+		campaigns[c.CampaignID] = t
+	}
+
+	global := e.smooth(all.clicks, all.impressions, e.prior)
+	campaignCTR := make(map[int64]float64, len(campaigns))
+	for id, t := range campaigns {
+		campaignCTR[id] = e.smooth(t.clicks, t.impressions, global)
+	}
+	creativeCTR := make(map[int64]float64, len(counts))
+	for _, c := range counts {
+		creativeCTR[c.CreativeID] = e.smooth(c.Clicks, c.Impressions, campaignCTR[c.CampaignID])
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.global = global
+	e.campaigns = campaignCTR
+	e.creatives = creativeCTR
+	return nil
+}
+
+// Run refreshes the estimates immediately and then every interval until
+// ctx is cancelled.
+func (e *Estimator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Refresh(ctx); err != nil && ctx.Err() == nil {
+			e.logger.Error("ctr estimates refresh failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CreativeCTR returns the creative estimate, falling back to the campaign
+// estimate for creatives without impressions.
+func (e *Estimator) CreativeCTR(campaignID, creativeID int64) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if v, ok := e.creatives[creativeID]; ok {
+		return v
+	}
+	return e.campaignCTR(campaignID)
+}
+
+// CampaignCTR returns the campaign estimate, falling back to the global
+// estimate for campaigns without impressions.
+func (e *Estimator) CampaignCTR(campaignID int64) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.campaignCTR(campaignID)
+}
+
+// GlobalCTR returns the estimate across all campaigns.
+func (e *Estimator) GlobalCTR() float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.global
+}
+
+// campaignCTR is CampaignCTR for callers holding mu.
+func (e *Estimator) campaignCTR(campaignID int64) float64 {
+	if v, ok := e.campaigns[campaignID]; ok {
+		return v
+	}
+	return e.global
+}
+
+// smooth blends the observed rate with parent as if weight impressions had
+// been observed at the parent rate.
+func (e *Estimator) smooth(clicks, impressions int64, parent float64) float64 {
+	if impressions <= 0 && e.weight <= 0 {
+		return parent
+	}
+	v := (float64(clicks) + parent*e.weight) / (float64(impressions) + e.weight)
+	return min(max(v, 0), 1)
+}
@@ -0,0 +1,90 @@
+package ctr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestEstimatorSmoothing checks the hierarchical blending of observed CTRs.
+func TestEstimatorSmoothing(t *testing.T) {
+	repo := mocks.NewMockCTRRepository(t)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().
+		ListEventCounts(mock.Anything, now.Add(-24*time.Hour)).
+		Return([]domain.EventCounts{
+			{CampaignID: 1, CreativeID: 10, Impressions: 900, Clicks: 45},
+			{CampaignID: 1, CreativeID: 11, Impressions: 100, Clicks: 5},
+			{CampaignID: 2, CreativeID: 20, Impressions: 1000, Clicks: 0},
+		}, nil)
+
+	e := NewEstimator(repo, slog.Default(), func() time.Time { return now }, 0.01, 100, 24*time.Hour)
+	if got := e.CreativeCTR(1, 10); got != 0.01 {
+		t.Fatalf("cold estimate = %v, want prior", got)
+	}
+	if err := e.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+
+	global := (50 + 0.01*100) / (2000 + 100.0)
+	campaign1 := (50 + global*100) / (1000 + 100)
+	campaign2 := (0 + global*100) / (1000 + 100)
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"global", e.GlobalCTR(), global},
+		{"campaign", e.CampaignCTR(1), campaign1},
+		{"unknown campaign", e.CampaignCTR(3), global},
+		{"creative", e.CreativeCTR(1, 10), (45 + campaign1*100) / (900 + 100)},
+		{"sparse creative", e.CreativeCTR(1, 11), (5 + campaign1*100) / (100 + 100)},
+		{"new creative", e.CreativeCTR(1, 12), campaign1},
+		{"no clicks", e.CreativeCTR(2, 20), (0 + campaign2*100) / (1000 + 100)},
+	}
+	for _, tt := range tests {
+		if !near(tt.got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if e.CreativeCTR(1, 10) <= e.CreativeCTR(2, 20) {
+		t.Fatalf("clicked creative should rank above unclicked one")
+	}
+}
+
+// TestEstimatorRefreshError ensures failed refreshes keep the previous estimates.
+func TestEstimatorRefreshError(t *testing.T) {
+	repo := mocks.NewMockCTRRepository(t)
+	repo.EXPECT().
+		ListEventCounts(mock.Anything, mock.Anything).
+		Return([]domain.EventCounts{{CampaignID: 1, CreativeID: 10, Impressions: 100, Clicks: 50}}, nil).
+		Once()
+	repo.EXPECT().
+		ListEventCounts(mock.Anything, mock.Anything).
+		Return(nil, errors.New("db down")).
+		Once()
+
+	e := NewEstimator(repo, slog.Default(), time.Now, 0.01, 10, time.Hour)
+	if err := e.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	before := e.CreativeCTR(1, 10)
+	if err := e.Refresh(context.Background()); err == nil {
+		t.Fatalf("expected refresh error")
+	}
+	if got := e.CreativeCTR(1, 10); got != before {
+		t.Fatalf("estimate changed after failed refresh: %v -> %v", before, got)
+	}
+}
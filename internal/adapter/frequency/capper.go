@@ -0,0 +1,126 @@
+package frequency
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// Capper implements port.FrequencyCapper on top of a port.FrequencyStore.
+// Campaign caps count the user's impressions of every creative of the
+// campaign, creative caps only those of the creative. When neither the
+// campaign nor the creative defines caps, the default caps apply to the
+// creative.
+type Capper struct {
+	store    port.FrequencyStore
+	defaults []domain.FrequencyCap
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewCapper creates a capper. defaults are the creative caps used for
+// candidates without caps of their own; now is the clock (time.Now in
+// production).
+func NewCapper(
+	store port.FrequencyStore,
+	defaults []domain.FrequencyCap,
+	logger *slog.Logger,
+	now func() time.Time,
+) *Capper {
+	return &Capper{store: store, defaults: defaults, logger: logger, now: now}
+}
+
+// Filter drops candidates for which the user has reached a cap. The
+// history of all candidate campaigns is loaded with a single store call.
+// Anonymous users are never capped.
+func (c *Capper) Filter(
+	ctx context.Context,
+	userID string,
+	candidates []port.CreativeCandidate,
+) ([]port.CreativeCandidate, error) {
+	if userID == "" || len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	var (
+		window      time.Duration
+		campaignIDs []int64
+	)
+	for _, cand := range candidates {
+		campaignCaps, creativeCaps := c.caps(cand)
+		for _, f := range slices.Concat(campaignCaps, creativeCaps) {
+			window = max(window, f.Window)
+		}
+		if !slices.Contains(campaignIDs, cand.Campaign.ID) {
+			campaignIDs = append(campaignIDs, cand.Campaign.ID)
+		}
+	}
+	if window == 0 {
+		return candidates, nil
+	}
+
+	now := c.now()
+	events, err := c.store.Events(ctx, userID, campaignIDs, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(candidates, func(cand port.CreativeCandidate) bool {
+		campaignCaps, creativeCaps := c.caps(cand)
+		for _, f := range campaignCaps {
+			if count(events, now.Add(-f.Window), func(e domain.FrequencyEvent) bool {
+				return e.CampaignID == cand.Campaign.ID
+			}) >= f.Limit {
+				return true
+			}
+		}
+		for _, f := range creativeCaps {
+			if count(events, now.Add(-f.Window), func(e domain.FrequencyEvent) bool {
+				return e.CreativeID == cand.Creative.ID
+			}) >= f.Limit {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// Record stores the reserved impression in the counter store. Store
+// failures are logged: the budget is already reserved.
+func (c *Capper) Record(ctx context.Context, imp domain.Impression) {
+	if imp.UserID == "" {
+		return
+	}
+	err := c.store.Record(ctx, imp.UserID, domain.FrequencyEvent{
+		CampaignID: imp.CampaignID,
+		CreativeID: imp.CreativeID,
+		At:         c.now(),
+	})
+	if err != nil {
+		c.logger.Error("failed to record frequency event",
+			slog.Int64("campaign_id", imp.CampaignID), slog.Any("error", err))
+	}
+}
+
+// caps returns the campaign and creative caps in force for a candidate.
+func (c *Capper) caps(cand port.CreativeCandidate) (campaign, creative []domain.FrequencyCap) {
+	if len(cand.Campaign.FrequencyCaps) == 0 && len(cand.Creative.FrequencyCaps) == 0 {
+		return nil, c.defaults
+	}
+	return cand.Campaign.FrequencyCaps, cand.Creative.FrequencyCaps
+}
+
+// count returns how many events after since satisfy match.
+func count(events []domain.FrequencyEvent, since time.Time, match func(e domain.FrequencyEvent) bool) int {
+	n := 0
+	for _, e := range events {
+		if e.At.After(since) && match(e) {
+			n++
+		}
+	}
+	return n
+}
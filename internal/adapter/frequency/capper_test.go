@@ -0,0 +1,186 @@
+package frequency
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestCapperFilter ensures campaign, creative and default caps are enforced over their windows.
+func TestCapperFilter(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	hourly := domain.FrequencyCap{Limit: 2, Window: time.Hour}
+	daily := domain.FrequencyCap{Limit: 3, Window: 24 * time.Hour}
+
+	tests := []struct {
+		name      string
+		campaign  []domain.FrequencyCap
+		creative  []domain.FrequencyCap
+		history   []domain.FrequencyEvent
+		wantAllow bool
+	}{
+		{
+			name:      "under creative cap",
+			creative:  []domain.FrequencyCap{hourly},
+			history:   []domain.FrequencyEvent{{CampaignID: 1, CreativeID: 10, At: now.Add(-time.Minute)}},
+			wantAllow: true,
+		},
+		{
+			name:     "creative cap reached",
+			creative: []domain.FrequencyCap{hourly},
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-time.Minute)},
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-2 * time.Minute)},
+			},
+		},
+		{
+			name:     "creative cap ignores sibling creatives",
+			creative: []domain.FrequencyCap{hourly},
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 11, At: now.Add(-time.Minute)},
+				{CampaignID: 1, CreativeID: 11, At: now.Add(-2 * time.Minute)},
+			},
+			wantAllow: true,
+		},
+		{
+			name:     "campaign cap counts sibling creatives",
+			campaign: []domain.FrequencyCap{hourly},
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 11, At: now.Add(-time.Minute)},
+				{CampaignID: 1, CreativeID: 12, At: now.Add(-2 * time.Minute)},
+			},
+		},
+		{
+			name:     "old impressions fall out of the window",
+			campaign: []domain.FrequencyCap{hourly},
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-time.Minute)},
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-2 * time.Hour)},
+			},
+			wantAllow: true,
+		},
+		{
+			name:     "daily window of several caps",
+			campaign: []domain.FrequencyCap{hourly, daily},
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-time.Minute)},
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-3 * time.Hour)},
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-20 * time.Hour)},
+			},
+		},
+		{
+			name: "defaults apply without caps",
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-time.Minute)},
+			},
+		},
+		{
+			name:     "own caps replace defaults",
+			campaign: []domain.FrequencyCap{hourly},
+			history: []domain.FrequencyEvent{
+				{CampaignID: 1, CreativeID: 10, At: now.Add(-time.Minute)},
+			},
+			wantAllow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			for _, e := range tt.history {
+				_ = store.Record(context.Background(), "u1", e)
+			}
+			defaults := []domain.FrequencyCap{{Limit: 1, Window: time.Hour}}
+			capper := NewCapper(store, defaults, slog.Default(), func() time.Time { return now })
+
+			got, err := capper.Filter(context.Background(), "u1", []port.CreativeCandidate{{
+				Creative: domain.Creative{ID: 10, CampaignID: 1, FrequencyCaps: tt.creative},
+				Campaign: domain.Campaign{ID: 1, FrequencyCaps: tt.campaign},
+			}})
+			if err != nil {
+				t.Fatalf("Filter error: %v", err)
+			}
+			if allowed := len(got) == 1; allowed != tt.wantAllow {
+				t.Fatalf("allowed = %v, want %v", allowed, tt.wantAllow)
+			}
+		})
+	}
+}
+
+// TestCapperSingleStoreCall ensures the history of all candidates is loaded at once.
+func TestCapperSingleStoreCall(t *testing.T) {
+	store := mocks.NewMockFrequencyStore(t)
+	now := time.Now()
+
+	store.EXPECT().
+		Events(mock.Anything, "u1", []int64{1, 2}, now.Add(-24*time.Hour)).
+		Return(nil, nil).
+		Once()
+
+	capper := NewCapper(store, []domain.FrequencyCap{{Limit: 3, Window: time.Hour}}, slog.Default(),
+		func() time.Time { return now })
+	candidates := []port.CreativeCandidate{
+		{Creative: domain.Creative{ID: 10}, Campaign: domain.Campaign{ID: 1}},
+		{Creative: domain.Creative{ID: 11}, Campaign: domain.Campaign{ID: 1}},
+		{
+			Creative: domain.Creative{ID: 20},
+			Campaign: domain.Campaign{ID: 2, FrequencyCaps: []domain.FrequencyCap{{Limit: 5, Window: 24 * time.Hour}}},
+		},
+	}
+	got, err := capper.Filter(context.Background(), "u1", candidates)
+	if err != nil || len(got) != 3 {
+		t.Fatalf("expected all candidates, got %d (%v)", len(got), err)
+	}
+}
+
+// TestCapperAnonymous ensures users without an id are never capped.
+func TestCapperAnonymous(t *testing.T) {
+	store := mocks.NewMockFrequencyStore(t)
+	capper := NewCapper(store, []domain.FrequencyCap{{Limit: 1, Window: time.Hour}}, slog.Default(), time.Now)
+
+	capper.Record(context.Background(), domain.Impression{CampaignID: 1, CreativeID: 10})
+	got, err := capper.Filter(context.Background(), "", []port.CreativeCandidate{{}})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("expected candidate, got %d (%v)", len(got), err)
+	}
+}
+
+// TestMemoryStoreSweep ensures events beyond the longest window are dropped.
+func TestMemoryStoreSweep(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	_ = store.Record(context.Background(), "u1", domain.FrequencyEvent{CampaignID: 1, At: now.Add(-domain.MaxFrequencyWindow - time.Minute)})
+	_ = store.Record(context.Background(), "u2", domain.FrequencyEvent{CampaignID: 1, At: now})
+
+	store.Sweep(now)
+	if len(store.events) != 1 || len(store.events["u2"]) != 1 {
+		t.Fatalf("unexpected events after sweep: %v", store.events)
+	}
+}
+
+// TestParseFrequencyCaps ensures the configuration format is parsed and validated.
+func TestParseFrequencyCaps(t *testing.T) {
+	caps, err := domain.ParseFrequencyCaps("3/1h, 10/24h")
+	if err != nil {
+		t.Fatalf("ParseFrequencyCaps error: %v", err)
+	}
+	want := []domain.FrequencyCap{{Limit: 3, Window: time.Hour}, {Limit: 10, Window: 24 * time.Hour}}
+	if len(caps) != 2 || caps[0] != want[0] || caps[1] != want[1] {
+		t.Fatalf("unexpected caps: %v", caps)
+	}
+	if caps, err = domain.ParseFrequencyCaps(""); err != nil || caps != nil {
+		t.Fatalf("expected no caps, got %v (%v)", caps, err)
+	}
+	for _, s := range []string{"3", "x/1h", "3/soon", "0/1h", "3/1000h"} {
+		if _, err = domain.ParseFrequencyCaps(s); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("%q: expected validation error, got %v", s, err)
+		}
+	}
+}
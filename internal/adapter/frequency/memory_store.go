@@ -0,0 +1,85 @@
+package frequency
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// MemoryStore implements port.FrequencyStore in process memory. It is
+// suitable for a single instance or for sticky routing of users to
+// instances; the history is lost on restart. Events older than
+// domain.MaxFrequencyWindow are dropped by Sweep.
+type MemoryStore struct {
+	mu     sync.Mutex
+	events map[string][]domain.FrequencyEvent
+}
+
+// NewMemoryStore creates an empty store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{events: make(map[string][]domain.FrequencyEvent)}
+}
+
+// Events returns the user's impressions of the given campaigns made after
+// since.
+func (s *MemoryStore) Events(
+	_ context.Context,
+	userID string,
+	campaignIDs []int64,
+	since time.Time,
+) ([]domain.FrequencyEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []domain.FrequencyEvent
+	for _, e := range s.events[userID] {
+		if e.At.After(since) && slices.Contains(campaignIDs, e.CampaignID) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Record appends an impression to the user's history.
+func (s *MemoryStore) Record(_ context.Context, userID string, event domain.FrequencyEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[userID] = append(s.events[userID], event)
+	return nil
+}
+
+// Sweep drops events that no cap can count any more and forgets users
+// without recent events.
+func (s *MemoryStore) Sweep(now time.Time) {
+	since := now.Add(-domain.MaxFrequencyWindow)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for user, events := range s.events {
+		events = slices.DeleteFunc(events, func(e domain.FrequencyEvent) bool {
+			return !e.At.After(since)
+		})
+		if len(events) == 0 {
+			delete(s.events, user)
+		} else {
+			s.events[user] = events
+		}
+	}
+}
+
+// Run sweeps the store every interval until ctx is cancelled.
+func (s *MemoryStore) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Sweep(now)
+		}
+	}
+}
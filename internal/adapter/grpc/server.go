@@ -0,0 +1,151 @@
+package grpcadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/pkg/adsv1"
+)
+
+// Server is an inbound adapter exposing the ad use case over gRPC. It
+// implements adsv1.AdServiceServer with the same semantics as the HTTP
+// handlers.
+type Server struct {
+	adsv1.UnimplementedAdServiceServer
+
+	svc    port.AdUseCase
+	logger *slog.Logger
+}
+
+// NewServer creates a gRPC server adapter for svc.
+func NewServer(svc port.AdUseCase, logger *slog.Logger) *Server {
+	return &Server{svc: svc, logger: logger}
+}
+
+// Register registers the ad service on r, usually a *grpc.Server.
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	adsv1.RegisterAdServiceServer(r, s)
+}
+
+// RequestAd selects an ad for the user context. A response without an ad
+// means that no creative matched.
+func (s *Server) RequestAd(ctx context.Context, req *adsv1.RequestAdRequest) (*adsv1.RequestAdResponse, error) {
+	ad, err := s.svc.RequestAd(ctx, domain.UserContext{
+		UserID:    req.GetUserId(),
+		Language:  req.GetLanguage(),
+		Geo:       req.GetGeo(),
+		Category:  req.GetCategory(),
+		Interests: req.GetInterests(),
+		Placement: req.GetPlacement(),
+		Floor:     req.GetFloor(),
+	})
+	if err != nil {
+		return nil, s.error("request ad", err)
+	}
+	if ad == nil {
+		return &adsv1.RequestAdResponse{}, nil
+	}
+	tracking := make(map[string]string, len(ad.TrackingURLs))
+	for event, u := range ad.TrackingURLs {
+		tracking[string(event)] = u
+	}
+	return &adsv1.RequestAdResponse{Ad: &adsv1.Ad{
+		ServingId:     ad.ServingID,
+		CampaignId:    ad.CampaignID,
+		CreativeId:    ad.CreativeID,
+		Title:         ad.Title,
+		Duration:      int32(ad.Duration),
+		VideoUrl:      ad.VideoURL,
+		ClickUrl:      ad.ClickURL,
+		ImpressionUrl: ad.ImpressionURL,
+		TrackingUrls:  tracking,
+		Price:         ad.Price,
+	}}, nil
+}
+
+// RegisterClick records a click and returns the landing URL. Like the HTTP
+// redirect, unknown tokens and internal errors are reported as NotFound to
+// avoid leaking information.
+func (s *Server) RegisterClick(ctx context.Context, req *adsv1.RegisterClickRequest) (*adsv1.RegisterClickResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing token")
+	}
+	landingURL, err := s.svc.RegisterClick(ctx, req.GetToken())
+	if errors.Is(err, domain.ErrInvalidToken) {
+		return nil, status.Error(codes.PermissionDenied, "invalid token")
+	}
+	if err != nil {
+		s.logger.Error("click error", slog.Any("error", err))
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &adsv1.RegisterClickResponse{LandingUrl: landingURL}, nil
+}
+
+// GetStats returns aggregated statistics. Without a period the last 24
+// hours are reported.
+func (s *Server) GetStats(ctx context.Context, req *adsv1.GetStatsRequest) (*adsv1.GetStatsResponse, error) {
+	now := time.Now()
+	statsReq := port.StatsReq{From: now.Add(-24 * time.Hour), To: now}
+	if req.From != nil {
+		if err := req.From.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid 'from' timestamp")
+		}
+		statsReq.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		if err := req.To.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid 'to' timestamp")
+		}
+		statsReq.To = req.To.AsTime()
+	}
+	if req.CampaignId != nil {
+		id := req.GetCampaignId()
+		statsReq.CampaignID = &id
+	}
+
+	stats, err := s.svc.GetStats(ctx, statsReq)
+	if err != nil {
+		return nil, s.error("stats", err)
+	}
+	events := make(map[string]int64, len(stats.Events))
+	for event, n := range stats.Events {
+		events[string(event)] = n
+	}
+	return &adsv1.GetStatsResponse{
+		Impressions:       stats.Impressions,
+		Clicks:            stats.Clicks,
+		Cost:              stats.Cost,
+		Events:            events,
+		EstimatedCtr:      stats.EstimatedCTR,
+		FirstQuartileRate: stats.FirstQuartileRate,
+		MidpointRate:      stats.MidpointRate,
+		ThirdQuartileRate: stats.ThirdQuartileRate,
+		CompletionRate:    stats.CompletionRate,
+		Reach:             stats.Reach,
+		AvgFrequency:      stats.AvgFrequency,
+	}, nil
+}
+
+// error maps use case errors to gRPC status errors. Unexpected errors are
+// logged and reported as Internal.
+func (s *Server) error(op string, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, port.ErrNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, domain.ErrInvalidToken):
+		return status.Error(codes.PermissionDenied, "invalid token")
+	default:
+		s.logger.Error(op+" error", slog.Any("error", err))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,178 @@
+package grpcadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+	"mesa-ads/pkg/adsv1"
+)
+
+// newTestClient serves svc on an in-process listener and returns a client
+// connected to it.
+func newTestClient(t *testing.T, svc port.AdUseCase) adsv1.AdServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	NewServer(svc, slog.Default()).Register(gs)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return adsv1.NewAdServiceClient(conn)
+}
+
+// TestRequestAd checks the mapping of the user context and the selected ad.
+func TestRequestAd(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().
+		RequestAd(mock.Anything, domain.UserContext{
+			UserID:    "u1",
+			Language:  "ru",
+			Geo:       "DE",
+			Interests: []string{"music"},
+			Placement: domain.PlacementPreRoll,
+			Floor:     250,
+		}).
+		Return(&port.AdResponse{
+			ServingID:     "tok",
+			CampaignID:    3,
+			CreativeID:    7,
+			Price:         1234,
+			Title:         "Summer sale",
+			Duration:      30,
+			VideoURL:      "https://cdn.example.com/video/7.mp4",
+			ClickURL:      "/api/v1/ad/click/tok",
+			ImpressionURL: "/api/v1/ad/impression/tok",
+			TrackingURLs:  map[domain.TrackingEvent]string{domain.EventStart: "/api/v1/ad/track/tok/start"},
+		}, nil).
+		Once()
+	svc.EXPECT().RequestAd(mock.Anything, domain.UserContext{UserID: "u2"}).Return(nil, nil).Once()
+	client := newTestClient(t, svc)
+
+	resp, err := client.RequestAd(t.Context(), &adsv1.RequestAdRequest{
+		UserId:    "u1",
+		Language:  "ru",
+		Geo:       "DE",
+		Interests: []string{"music"},
+		Placement: domain.PlacementPreRoll,
+		Floor:     250,
+	})
+	if err != nil {
+		t.Fatalf("RequestAd error: %v", err)
+	}
+	want := &adsv1.Ad{
+		ServingId:     "tok",
+		CampaignId:    3,
+		CreativeId:    7,
+		Title:         "Summer sale",
+		Duration:      30,
+		VideoUrl:      "https://cdn.example.com/video/7.mp4",
+		ClickUrl:      "/api/v1/ad/click/tok",
+		ImpressionUrl: "/api/v1/ad/impression/tok",
+		TrackingUrls:  map[string]string{"start": "/api/v1/ad/track/tok/start"},
+		Price:         1234,
+	}
+	if !proto.Equal(resp.GetAd(), want) {
+		t.Fatalf("ad = %v, want %v", resp.GetAd(), want)
+	}
+
+	resp, err = client.RequestAd(t.Context(), &adsv1.RequestAdRequest{UserId: "u2"})
+	if err != nil || resp.GetAd() != nil {
+		t.Fatalf("expected no fill, got %v, %v", resp, err)
+	}
+}
+
+// TestRegisterClick checks the landing URL and the status codes of clicks.
+func TestRegisterClick(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().RegisterClick(mock.Anything, "tok").Return("https://example.com/landing", nil)
+	svc.EXPECT().RegisterClick(mock.Anything, "forged").Return("", domain.ErrInvalidToken)
+	svc.EXPECT().RegisterClick(mock.Anything, "gone").Return("", errors.New("no rows"))
+	client := newTestClient(t, svc)
+
+	resp, err := client.RegisterClick(t.Context(), &adsv1.RegisterClickRequest{Token: "tok"})
+	if err != nil || resp.GetLandingUrl() != "https://example.com/landing" {
+		t.Fatalf("unexpected click response: %v, %v", resp, err)
+	}
+	for token, want := range map[string]codes.Code{
+		"":       codes.InvalidArgument,
+		"forged": codes.PermissionDenied,
+		"gone":   codes.NotFound,
+	} {
+		_, err := client.RegisterClick(t.Context(), &adsv1.RegisterClickRequest{Token: token})
+		if status.Code(err) != want {
+			t.Errorf("%q: code = %v, want %v", token, status.Code(err), want)
+		}
+	}
+}
+
+// TestGetStats checks the stats request defaults and the response mapping.
+func TestGetStats(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	campaignID := int64(3)
+
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().
+		GetStats(mock.Anything, port.StatsReq{From: from, To: to, CampaignID: &campaignID}).
+		Return(&port.StatsResp{
+			Impressions:    100,
+			Clicks:         4,
+			Cost:           250,
+			Events:         map[domain.TrackingEvent]int64{domain.EventComplete: 60},
+			EstimatedCTR:   0.04,
+			CompletionRate: 0.6,
+			Reach:          40,
+			AvgFrequency:   2.5,
+		}, nil).
+		Once()
+	svc.EXPECT().
+		GetStats(mock.Anything, mock.MatchedBy(func(req port.StatsReq) bool {
+			return req.CampaignID == nil && req.To.Sub(req.From) == 24*time.Hour
+		})).
+		Return(nil, errors.New("db down")).
+		Once()
+	client := newTestClient(t, svc)
+
+	resp, err := client.GetStats(t.Context(), &adsv1.GetStatsRequest{
+		From:       timestamppb.New(from),
+		To:         timestamppb.New(to),
+		CampaignId: &campaignID,
+	})
+	if err != nil {
+		t.Fatalf("GetStats error: %v", err)
+	}
+	if resp.GetImpressions() != 100 || resp.GetClicks() != 4 || resp.GetCost() != 250 ||
+		resp.GetEvents()["complete"] != 60 || resp.GetCompletionRate() != 0.6 ||
+		resp.GetReach() != 40 || resp.GetAvgFrequency() != 2.5 {
+		t.Fatalf("unexpected stats: %v", resp)
+	}
+
+	_, err = client.GetStats(t.Context(), &adsv1.GetStatsRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code = %v, want Internal", status.Code(err))
+	}
+}
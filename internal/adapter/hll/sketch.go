@@ -0,0 +1,194 @@
+package hll
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const (
+	// Precision is the number of hash bits selecting a register. 2^14
+	// registers give a standard error of about 0.8%.
+	Precision = 14
+	registers = 1 << Precision
+	// maxRank is the largest register value: the position of the first set
+	// bit in the remaining 64-Precision hash bits, or one past them.
+	maxRank = 64 - Precision + 1
+
+	formatSparse byte = 1
+	formatDense  byte = 2
+)
+
+// ErrInvalidSketch is returned when decoding bytes that are not a sketch
+// of this package.
+var ErrInvalidSketch = errors.New("invalid hll sketch")
+
+// Sketch is a HyperLogLog sketch estimating the number of distinct values
+// added to it. Sketches are mergeable: the merge of two sketches estimates
+// the size of the union of their values, so sketches stored per hour can
+// answer any range of hours. The zero value is an empty sketch. A Sketch
+// is not safe for concurrent use.
+type Sketch struct {
+	regs []uint8
+}
+
+// New returns an empty sketch.
+func New() *Sketch {
+	return &Sketch{}
+}
+
+// Add adds a value to the sketch. The value is hashed to the first 64 bits
+// of its MD5, big-endian: the top Precision bits select the register and
+// the rank is the position of the first set bit in the rest. A database
+// can compute the same register and rank, see AddRegister.
+func (s *Sketch) Add(value string) {
+	sum := md5.Sum([]byte(value))
+	x := binary.BigEndian.Uint64(sum[:8])
+	s.AddRegister(int(x>>(64-Precision)), uint8(bits.LeadingZeros64(x<<Precision|1<<(Precision-1))+1))
+}
+
+// AddRegister adds a value whose register and rank were computed outside
+// the sketch, such as in SQL, from the hash described by Add. Out of range
+// registers and ranks are ignored.
+func (s *Sketch) AddRegister(idx int, rank uint8) {
+	if idx < 0 || idx >= registers || rank > maxRank {
+		return
+	}
+	if s.regs == nil {
+		s.regs = make([]uint8, registers)
+	}
+	if rank > s.regs[idx] {
+		s.regs[idx] = rank
+	}
+}
+
+// Merge adds the values of other to the sketch.
+func (s *Sketch) Merge(other *Sketch) {
+	if other == nil || other.regs == nil {
+		return
+	}
+	if s.regs == nil {
+		s.regs = make([]uint8, registers)
+	}
+	for i, r := range other.regs {
+		if r > s.regs[i] {
+			s.regs[i] = r
+		}
+	}
+}
+
+// Estimate returns the estimated number of distinct values. It uses the
+// improved estimator of Ertl ("New cardinality estimation algorithms for
+// HyperLogLog sketches", 2017), which needs no empirical bias correction
+// and stays accurate from a handful of values to billions.
+func (s *Sketch) Estimate() uint64 {
+	if s.regs == nil {
+		return 0
+	}
+	var counts [maxRank + 1]int
+	for _, r := range s.regs {
+		counts[r]++
+	}
+	const m = float64(registers)
+	z := m * tau(1-float64(counts[maxRank])/m)
+	for k := maxRank - 1; k >= 1; k-- {
+		z = 0.5 * (z + float64(counts[k]))
+	}
+	z += m * sigma(float64(counts[0])/m)
+	return uint64(math.Round(m * m / (2 * math.Ln2) / z))
+}
+
+// MarshalBinary encodes the sketch. Sketches with few non-empty registers,
+// such as those of a single campaign hour, are stored as a list of
+// registers instead of the full array.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	var used int
+	for _, r := range s.regs {
+		if r != 0 {
+			used++
+		}
+	}
+	if used*3 >= registers {
+		return append([]byte{formatDense}, s.regs...), nil
+	}
+	buf := make([]byte, 1, 1+used*3)
+	buf[0] = formatSparse
+	for i, r := range s.regs {
+		if r != 0 {
+			buf = binary.BigEndian.AppendUint16(buf, uint16(i))
+			buf = append(buf, r)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the sketch with one encoded by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidSketch
+	}
+	regs := make([]uint8, registers)
+	switch body := data[1:]; data[0] {
+	case formatDense:
+		if len(body) != registers {
+			return ErrInvalidSketch
+		}
+		copy(regs, body)
+	case formatSparse:
+		if len(body)%3 != 0 {
+			return ErrInvalidSketch
+		}
+		for ; len(body) > 0; body = body[3:] {
+			idx := binary.BigEndian.Uint16(body)
+			if idx >= registers {
+				return ErrInvalidSketch
+			}
+			regs[idx] = body[2]
+		}
+	default:
+		return ErrInvalidSketch
+	}
+	for _, r := range regs {
+		if r > maxRank {
+			return ErrInvalidSketch
+		}
+	}
+	s.regs = regs
+	return nil
+}
+
+// sigma and tau are the correction series of the improved estimator for
+// empty and saturated registers.
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package hll
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// tolerance is the allowed relative error: four standard errors of a
+// sketch with 2^14 registers.
+const tolerance = 4 * 1.04 / 128
+
+func relErr(got uint64, want int) float64 {
+	return math.Abs(float64(got)-float64(want)) / float64(want)
+}
+
+// TestEstimateAccuracy compares estimates with exact distinct counts from
+// small to large cardinalities, with every value added several times.
+func TestEstimateAccuracy(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 1000, 5000, 20000, 50000, 200000, 1000000} {
+		s := New()
+		for i := 0; i < n; i++ {
+			id := "user-" + strconv.Itoa(i)
+			s.Add(id)
+			if i%3 == 0 {
+				s.Add(id)
+			}
+		}
+		got := s.Estimate()
+		if n <= 100 && got != uint64(n) {
+			t.Errorf("n=%d: estimate %d, want exact", n, got)
+		}
+		if e := relErr(got, n); e > tolerance {
+			t.Errorf("n=%d: estimate %d, relative error %.4f", n, got, e)
+		}
+	}
+	if got := New().Estimate(); got != 0 {
+		t.Fatalf("empty sketch estimate = %d", got)
+	}
+}
+
+// TestMergeHourly builds a sketch per campaign hour from synthetic traffic
+// where users return across hours and checks that merging any range of
+// hours estimates the exact reach of that range.
+func TestMergeHourly(t *testing.T) {
+	const (
+		hours = 48
+		users = 30000
+	)
+	rng := rand.New(rand.NewSource(1))
+	sketches := make([]*Sketch, hours)
+	seen := make([]map[int]bool, hours)
+	for h := range sketches {
+		sketches[h] = New()
+		seen[h] = make(map[int]bool)
+		for i := 0; i < 2000; i++ {
+			// a skewed audience: a core of regular viewers and a long tail
+			u := rng.Intn(users)
+			if rng.Intn(2) == 0 {
+				u = rng.Intn(users / 20)
+			}
+			sketches[h].Add("user-" + strconv.Itoa(u))
+			seen[h][u] = true
+		}
+	}
+
+	for _, r := range [][2]int{{0, 1}, {0, 6}, {5, 29}, {0, hours}} {
+		merged := New()
+		exact := make(map[int]bool)
+		for h := r[0]; h < r[1]; h++ {
+			merged.Merge(sketches[h])
+			for u := range seen[h] {
+				exact[u] = true
+			}
+		}
+		if e := relErr(merged.Estimate(), len(exact)); e > tolerance {
+			t.Errorf("hours %v: estimate %d, exact %d", r, merged.Estimate(), len(exact))
+		}
+	}
+}
+
+// TestMarshalRoundTrip checks both encodings and rejection of garbage.
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, n := range []int{0, 50, 100000} {
+		s := New()
+		for i := 0; i < n; i++ {
+			s.Add(strconv.Itoa(i))
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary error: %v", err)
+		}
+		if n == 50 && len(data) > 200 {
+			t.Errorf("small sketch encoded in %d bytes", len(data))
+		}
+		var got Sketch
+		if err = got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("n=%d: UnmarshalBinary error: %v", n, err)
+		}
+		if got.Estimate() != s.Estimate() {
+			t.Errorf("n=%d: decoded estimate %d, want %d", n, got.Estimate(), s.Estimate())
+		}
+	}
+
+	for _, data := range [][]byte{nil, {9}, {formatDense, 1, 2}, {formatSparse, 0xff, 0xff, 1}, {formatSparse, 0, 1, 99}} {
+		var s Sketch
+		if err := s.UnmarshalBinary(data); err != ErrInvalidSketch {
+			t.Errorf("UnmarshalBinary(%v) = %v, want ErrInvalidSketch", data, err)
+		}
+	}
+}
+
+// TestAddRegister builds a sketch from registers computed the way the SQL
+// of the postgres adapter does, on the bit string of the first 16 hex
+// digits of the MD5, and checks it equals a sketch built with Add.
+func TestAddRegister(t *testing.T) {
+	added, registered := New(), New()
+	for i := 0; i < 5000; i++ {
+		id := "user-" + strconv.Itoa(i)
+		added.Add(id)
+
+		var h strings.Builder
+		sum := md5.Sum([]byte(id))
+		for _, b := range sum[:8] {
+			fmt.Fprintf(&h, "%08b", b)
+		}
+		bits := h.String()
+		idx, err := strconv.ParseInt(bits[:Precision], 2, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rank := strings.Index(bits[Precision:]+"1", "1") + 1
+		registered.AddRegister(int(idx), uint8(rank))
+	}
+
+	want, _ := added.MarshalBinary()
+	got, _ := registered.MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Fatal("sketch from registers differs from sketch from values")
+	}
+
+	registered.AddRegister(registers, 1)
+	registered.AddRegister(0, maxRank+1)
+	if got, _ = registered.MarshalBinary(); !bytes.Equal(got, want) {
+		t.Fatal("out of range register changed the sketch")
+	}
+}
@@ -0,0 +1,37 @@
+package httpadapter
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// requireAPIKey returns a middleware admitting only requests that present
+// one of keys as an `Authorization: Bearer` token. Keys are compared by
+// their SHA-256 digests in constant time, so neither the content nor the
+// length of a key leaks through response timing.
+func requireAPIKey(keys []string) func(http.Handler) http.Handler {
+	digests := make([][sha256.Size]byte, len(keys))
+	for i, key := range keys {
+		digests[i] = sha256.Sum256([]byte(key))
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if ok {
+				sum := sha256.Sum256([]byte(key))
+				match := 0
+				for _, digest := range digests {
+					match |= subtle.ConstantTimeCompare(sum[:], digest[:])
+				}
+				if match == 1 {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mesa-ads"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package httpadapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// campaignRequest is the body accepted by the create and update campaign
+// endpoints. Remaining budgets and status are managed by the service and
+// cannot be set directly.
+type campaignRequest struct {
+	Name          string
+	StartDate     time.Time
+	EndDate       time.Time
+	DailyBudget   int64
+	TotalBudget   int64
+	CPMBid        int64
+	CPCBid        int64
+	PacingMode    domain.PacingMode
+	FrequencyCaps []domain.FrequencyCap
+}
+
+func (c campaignRequest) toDomain(id int64) domain.Campaign {
+	return domain.Campaign{
+		ID:            id,
+		Name:          c.Name,
+		StartDate:     c.StartDate,
+		EndDate:       c.EndDate,
+		DailyBudget:   c.DailyBudget,
+		TotalBudget:   c.TotalBudget,
+		CPMBid:        c.CPMBid,
+		CPCBid:        c.CPCBid,
+		PacingMode:    c.PacingMode,
+		FrequencyCaps: c.FrequencyCaps,
+	}
+}
+
+// handleListCampaigns returns campaigns ordered by id. It accepts optional
+// `status`, `limit` and `offset` query parameters.
+func (h *Handler) handleListCampaigns(w http.ResponseWriter, r *http.Request) {
+	page, ok := queryPage(r)
+	if !ok {
+		http.Error(w, "invalid pagination", http.StatusBadRequest)
+		return
+	}
+	campaigns, err := h.campaigns.ListCampaigns(r.Context(), port.ListCampaignsReq{
+		Status: domain.CampaignStatus(r.URL.Query().Get("status")),
+		Page:   page,
+	})
+	if err != nil {
+		h.writeError(w, "list campaigns", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, campaigns)
+}
+
+// handleCreateCampaign creates a campaign from a campaignRequest body and
+// returns it with HTTP 201. Invalid campaigns result in HTTP 400.
+func (h *Handler) handleCreateCampaign(w http.ResponseWriter, r *http.Request) {
+	var req campaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	c, err := h.campaigns.CreateCampaign(r.Context(), req.toDomain(0))
+	if err != nil {
+		h.writeError(w, "create campaign", err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, c)
+}
+
+// handleGetCampaign returns the campaign bound to the {id} path parameter.
+func (h *Handler) handleGetCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	c, err := h.campaigns.GetCampaign(r.Context(), id)
+	if err != nil {
+		h.writeError(w, "get campaign", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, c)
+}
+
+// handleUpdateCampaign replaces the editable fields of a campaign.
+func (h *Handler) handleUpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	var req campaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	c, err := h.campaigns.UpdateCampaign(r.Context(), req.toDomain(id))
+	if err != nil {
+		h.writeError(w, "update campaign", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, c)
+}
+
+// statusRequest is the body accepted by the change status endpoint.
+type statusRequest struct {
+	Status domain.CampaignStatus
+}
+
+// actorHeader identifies who performs a campaign status change. Requests
+// without it are attributed to defaultActor.
+const (
+	actorHeader  = "X-Actor"
+	defaultActor = "api"
+)
+
+// handleChangeStatus moves a campaign to the status given in the body.
+// Illegal transitions result in HTTP 409.
+func (h *Handler) handleChangeStatus(w http.ResponseWriter, r *http.Request) {
+	var req statusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	h.changeCampaignStatus(w, r, req.Status)
+}
+
+// handlePauseCampaign pauses an active campaign.
+func (h *Handler) handlePauseCampaign(w http.ResponseWriter, r *http.Request) {
+	h.changeCampaignStatus(w, r, domain.CampaignStatusPaused)
+}
+
+// handleResumeCampaign re-activates a paused or budget exhausted campaign.
+func (h *Handler) handleResumeCampaign(w http.ResponseWriter, r *http.Request) {
+	h.changeCampaignStatus(w, r, domain.CampaignStatusActive)
+}
+
+// handleArchiveCampaign archives a campaign.
+func (h *Handler) handleArchiveCampaign(w http.ResponseWriter, r *http.Request) {
+	h.changeCampaignStatus(w, r, domain.CampaignStatusArchived)
+}
+
+// changeCampaignStatus moves the campaign bound to the {id} path parameter
+// to the given status on behalf of the actor named in the X-Actor header.
+func (h *Handler) changeCampaignStatus(w http.ResponseWriter, r *http.Request, to domain.CampaignStatus) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	actor := r.Header.Get(actorHeader)
+	if actor == "" {
+		actor = defaultActor
+	}
+	c, err := h.campaigns.ChangeStatus(r.Context(), id, to, actor)
+	if err != nil {
+		h.writeError(w, "change campaign status", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, c)
+}
+
+// handleListTransitions returns the status history of a campaign.
+func (h *Handler) handleListTransitions(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	transitions, err := h.campaigns.ListTransitions(r.Context(), id)
+	if err != nil {
+		h.writeError(w, "list transitions", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, transitions)
+}
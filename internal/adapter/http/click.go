@@ -1,17 +1,20 @@
 package httpadapter
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+
+	"mesa-ads/internal/core/domain"
 )
 
 // handleAdClick handles click redirects and records click events. It expects
 // a {token} path parameter bound by the router. On success it redirects
-// the user to the landing URL. Missing or invalid tokens result in
-// HTTP 400, while unknown tokens result in HTTP 404. Internal errors are
-// logged and treated as 404 to avoid leaking information.
+// the user to the landing URL. Missing tokens result in HTTP 400, forged
+// or expired tokens in HTTP 403 and unknown tokens in HTTP 404. Internal
+// errors are logged and treated as 404 to avoid leaking information.
 func (h *Handler) handleAdClick(w http.ResponseWriter, r *http.Request) {
 	token := chi.URLParam(r, "token")
 	if token == "" {
@@ -19,6 +22,10 @@ func (h *Handler) handleAdClick(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	landingURL, err := h.svc.RegisterClick(r.Context(), token)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		h.logger.Error("click error", slog.Any("error", err))
 		http.NotFound(w, r)
@@ -0,0 +1,138 @@
+package httpadapter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// creativeRequest is the body accepted by the create and update creative
+// endpoints.
+type creativeRequest struct {
+	Title         string
+	VideoURL      string
+	LandingURL    string
+	Duration      int
+	Language      string
+	Category      string
+	Placement     string
+	FrequencyCaps []domain.FrequencyCap
+}
+
+func (c creativeRequest) toDomain(campaignID, id int64) domain.Creative {
+	return domain.Creative{
+		ID:            id,
+		CampaignID:    campaignID,
+		Title:         c.Title,
+		VideoURL:      c.VideoURL,
+		LandingURL:    c.LandingURL,
+		Duration:      c.Duration,
+		Language:      c.Language,
+		Category:      c.Category,
+		Placement:     c.Placement,
+		FrequencyCaps: c.FrequencyCaps,
+	}
+}
+
+// handleListCreatives returns a page of the campaign's creatives. It
+// accepts optional `limit` and `offset` query parameters.
+func (h *Handler) handleListCreatives(w http.ResponseWriter, r *http.Request) {
+	campaignID, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	page, ok := queryPage(r)
+	if !ok {
+		http.Error(w, "invalid pagination", http.StatusBadRequest)
+		return
+	}
+	list, err := h.creatives.ListCreatives(r.Context(), campaignID, page)
+	if err != nil {
+		h.writeError(w, "list creatives", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, list)
+}
+
+// handleCreateCreative creates a creative for the campaign and returns it
+// with HTTP 201. Invalid creatives result in HTTP 400.
+func (h *Handler) handleCreateCreative(w http.ResponseWriter, r *http.Request) {
+	campaignID, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	var req creativeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	cr, err := h.creatives.CreateCreative(r.Context(), req.toDomain(campaignID, 0))
+	if err != nil {
+		h.writeError(w, "create creative", err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, cr)
+}
+
+// handleGetCreative returns the creative bound to the {creativeID} path
+// parameter.
+func (h *Handler) handleGetCreative(w http.ResponseWriter, r *http.Request) {
+	campaignID, id, ok := creativePath(r)
+	if !ok {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	cr, err := h.creatives.GetCreative(r.Context(), campaignID, id)
+	if err != nil {
+		h.writeError(w, "get creative", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, cr)
+}
+
+// handleUpdateCreative replaces the editable fields of a creative.
+func (h *Handler) handleUpdateCreative(w http.ResponseWriter, r *http.Request) {
+	campaignID, id, ok := creativePath(r)
+	if !ok {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	var req creativeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	cr, err := h.creatives.UpdateCreative(r.Context(), req.toDomain(campaignID, id))
+	if err != nil {
+		h.writeError(w, "update creative", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, cr)
+}
+
+// handleDeleteCreative deletes a creative and returns HTTP 204. Creatives
+// that were already served result in HTTP 409.
+func (h *Handler) handleDeleteCreative(w http.ResponseWriter, r *http.Request) {
+	campaignID, id, ok := creativePath(r)
+	if !ok {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	if err := h.creatives.DeleteCreative(r.Context(), campaignID, id); err != nil {
+		h.writeError(w, "delete creative", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// creativePath parses the {id} and {creativeID} path parameters.
+func creativePath(r *http.Request) (campaignID, id int64, ok bool) {
+	if campaignID, ok = pathID(r, "id"); !ok {
+		return 0, 0, false
+	}
+	id, ok = pathID(r, "creativeID")
+	return campaignID, id, ok
+}
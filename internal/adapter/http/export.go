@@ -0,0 +1,191 @@
+package httpadapter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mesa-ads/internal/core/port"
+)
+
+// exportFlushEvery is the number of events written between flushes of the
+// response, so clients receive the export progressively.
+const exportFlushEvery = 500
+
+// exportColumns is the CSV header of an export.
+var exportColumns = []string{
+	"cursor", "type", "id", "token", "campaign_id", "creative_id", "user_id", "cost", "created_at",
+}
+
+// exportedEvent is the NDJSON form of an exported event.
+type exportedEvent struct {
+	Cursor     string    `json:"cursor"`
+	Type       string    `json:"type"`
+	ID         int64     `json:"id"`
+	Token      string    `json:"token"`
+	CampaignID int64     `json:"campaign_id"`
+	CreativeID int64     `json:"creative_id"`
+	UserID     string    `json:"user_id"`
+	Cost       int64     `json:"cost"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// eventWriter encodes exported events in one of the export formats.
+type eventWriter interface {
+	begin() error
+	write(e port.ExportedEvent) error
+	flush() error
+}
+
+type csvEventWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvEventWriter) begin() error {
+	return c.w.Write(exportColumns)
+}
+
+func (c *csvEventWriter) write(e port.ExportedEvent) error {
+	return c.w.Write([]string{
+		e.Cursor,
+		string(e.Kind),
+		strconv.FormatInt(e.ID, 10),
+		e.Token,
+		strconv.FormatInt(e.CampaignID, 10),
+		strconv.FormatInt(e.CreativeID, 10),
+		e.UserID,
+		strconv.FormatInt(e.Cost, 10),
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+func (c *csvEventWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonEventWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (n *ndjsonEventWriter) begin() error {
+	return nil
+}
+
+func (n *ndjsonEventWriter) write(e port.ExportedEvent) error {
+	return n.enc.Encode(exportedEvent{
+		Cursor:     e.Cursor,
+		Type:       string(e.Kind),
+		ID:         e.ID,
+		Token:      e.Token,
+		CampaignID: e.CampaignID,
+		CreativeID: e.CreativeID,
+		UserID:     e.UserID,
+		Cost:       e.Cost,
+		CreatedAt:  e.CreatedAt.UTC(),
+	})
+}
+
+func (n *ndjsonEventWriter) flush() error {
+	return n.buf.Flush()
+}
+
+// handleExportEvents streams the impressions and clicks made in [from, to)
+// as CSV (`format=csv`, the default) or NDJSON (`format=ndjson`), oldest
+// first. `from` and `to` are required RFC3339 timestamps, `campaign_id`
+// is optional. Every event carries a cursor; repeating the request with
+// `cursor` set to the last received one resumes the export after it.
+// Invalid parameters result in HTTP 400. An error after streaming started
+// aborts the response, so a client never mistakes a truncated export for
+// a complete one.
+func (h *Handler) handleExportEvents(w http.ResponseWriter, r *http.Request) {
+	req, msg := exportReqFromQuery(r)
+	if msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	var (
+		out         eventWriter
+		contentType string
+	)
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "csv":
+		out, contentType = &csvEventWriter{w: csv.NewWriter(w)}, "text/csv; charset=utf-8"
+	case "ndjson":
+		buf := bufio.NewWriter(w)
+		out, contentType = &ndjsonEventWriter{buf: buf, enc: json.NewEncoder(buf)}, "application/x-ndjson"
+	default:
+		http.Error(w, "invalid 'format', expected csv or ndjson", http.StatusBadRequest)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	started, written := false, 0
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		return out.begin()
+	}
+	err := h.export.ExportEvents(r.Context(), req, func(e port.ExportedEvent) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := out.write(e); err != nil {
+			return err
+		}
+		if written++; written%exportFlushEvery == 0 {
+			if err := out.flush(); err != nil {
+				return err
+			}
+			return rc.Flush()
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = out.flush()
+	}
+	switch {
+	case err == nil:
+	case !started:
+		h.writeError(w, "export events", err)
+	default:
+		h.logger.Error("export events aborted", slog.Any("error", err), slog.Int("events", written))
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// exportReqFromQuery reads the period, campaign and cursor of an export.
+// It returns a non-empty message describing the first invalid parameter.
+func exportReqFromQuery(r *http.Request) (port.ExportReq, string) {
+	var (
+		q   = r.URL.Query()
+		req = port.ExportReq{Cursor: q.Get("cursor")}
+		err error
+	)
+	if req.From, err = time.Parse(time.RFC3339, q.Get("from")); err != nil {
+		return req, "invalid or missing 'from' timestamp"
+	}
+	if req.To, err = time.Parse(time.RFC3339, q.Get("to")); err != nil {
+		return req, "invalid or missing 'to' timestamp"
+	}
+	if cid := q.Get("campaign_id"); cid != "" {
+		id, err := strconv.ParseInt(cid, 10, 64)
+		if err != nil {
+			return req, "invalid campaign_id"
+		}
+		req.CampaignID = &id
+	}
+	return req, ""
+}
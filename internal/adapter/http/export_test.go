@@ -0,0 +1,150 @@
+package httpadapter
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+func exportEvents(n int) []port.ExportedEvent {
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	events := make([]port.ExportedEvent, n)
+	for i := range events {
+		events[i] = port.ExportedEvent{
+			Cursor:     "c" + string(rune('a'+i%26)),
+			Kind:       port.EventKindImpression,
+			ID:         int64(i + 1),
+			Token:      "tok",
+			CampaignID: 3,
+			CreativeID: 7,
+			UserID:     "u1",
+			Cost:       5,
+			CreatedAt:  at.Add(time.Duration(i) * time.Second),
+		}
+	}
+	return events
+}
+
+func newExportRouter(t *testing.T, events []port.ExportedEvent, err error) http.Handler {
+	svc := mocks.NewMockExportUseCase(t)
+	svc.EXPECT().
+		ExportEvents(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ port.ExportReq, fn func(port.ExportedEvent) error) error {
+			for _, e := range events {
+				if err := fn(e); err != nil {
+					return err
+				}
+			}
+			return err
+		}).
+		Maybe()
+	return NewHandler(mocks.NewMockAdUseCase(t), slog.Default(), WithExport(svc, []string{"secret", " "})).Router()
+}
+
+func exportRequest(query, key string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/export/events?from=2025-06-01T00:00:00Z&to=2025-06-02T00:00:00Z"+query, nil)
+	if key != "" {
+		r.Header.Set("Authorization", "Bearer "+key)
+	}
+	return r
+}
+
+// TestExportAuth ensures the export requires a configured API key and is
+// not registered without one.
+func TestExportAuth(t *testing.T) {
+	router := newExportRouter(t, nil, nil)
+	for _, key := range []string{"", "wrong", "secre"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, exportRequest("", key))
+		if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("key %q: status = %d, want 401 with challenge", key, rec.Code)
+		}
+	}
+
+	disabled := NewHandler(mocks.NewMockAdUseCase(t), slog.Default(),
+		WithExport(mocks.NewMockExportUseCase(t), nil)).Router()
+	rec := httptest.NewRecorder()
+	disabled.ServeHTTP(rec, exportRequest("", "secret"))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("export without keys: status = %d, want 404", rec.Code)
+	}
+}
+
+// TestExportFormats checks the CSV and NDJSON encodings.
+func TestExportFormats(t *testing.T) {
+	events := exportEvents(exportFlushEvery + 10)
+	router := newExportRouter(t, events, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, exportRequest("", "secret"))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Fatalf("csv: status = %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != len(events)+1 || records[0][0] != "cursor" {
+		t.Fatalf("csv has %d records, header %v", len(records), records[0])
+	}
+	if got := records[1]; got[0] != "ca" || got[1] != "impression" || got[2] != "1" || got[8] != "2025-06-01T00:00:00Z" {
+		t.Fatalf("unexpected csv record %v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, exportRequest("&format=ndjson&campaign_id=3", "secret"))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("ndjson: status = %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	lines := 0
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var e exportedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		if e.ID != events[lines].ID || e.Cursor != events[lines].Cursor || e.Type != "impression" {
+			t.Fatalf("line %d: unexpected event %+v", lines, e)
+		}
+		lines++
+	}
+	if lines != len(events) {
+		t.Fatalf("ndjson has %d lines, want %d", lines, len(events))
+	}
+}
+
+// TestExportErrors checks request validation and that a failure in the
+// middle of the stream aborts the response instead of ending it cleanly.
+func TestExportErrors(t *testing.T) {
+	router := newExportRouter(t, exportEvents(3), errors.New("connection reset"))
+	for _, r := range []*http.Request{
+		exportRequest("&format=xml", "secret"),
+		httptest.NewRequest(http.MethodGet, "/api/v1/export/events?to=2025-06-02T00:00:00Z", nil),
+	} {
+		r.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, r)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", r.URL.RawQuery, rec.Code)
+		}
+	}
+
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Fatalf("recovered %v, want http.ErrAbortHandler", p)
+		}
+	}()
+	router.ServeHTTP(httptest.NewRecorder(), exportRequest("", "secret"))
+}
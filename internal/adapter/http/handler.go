@@ -3,8 +3,11 @@ package httpadapter
 import (
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"mesa-ads/internal/core/port"
 )
@@ -14,22 +17,151 @@ import (
 // logging. Routes are registered on a chi.Router for convenient method
 // handling.
 type Handler struct {
-	svc    port.AdUseCase
-	logger *slog.Logger
-	router chi.Router
+	svc       port.AdUseCase
+	campaigns port.CampaignUseCase
+	creatives port.CreativeUseCase
+	logger    *slog.Logger
+	router    chi.Router
+
+	// rtbCurrency is the currency of OpenRTB prices and rtbMinorUnits the
+	// number of minor units, in which budgets are kept, per currency unit.
+	// An empty currency disables the OpenRTB bidder.
+	rtbCurrency   string
+	rtbMinorUnits float64
+
+	// export streams raw events to clients holding one of exportKeys.
+	export     port.ExportUseCase
+	exportKeys []string
+
+	// metrics serves the Prometheus scrape endpoint when set.
+	metrics http.Handler
+
+	// health reports readiness on /readyz when set.
+	health port.HealthUseCase
+
+	// tracer records server spans of ad requests. It is a no-op unless
+	// WithTracing is given.
+	tracer trace.Tracer
+}
+
+// Option configures optional dependencies of a Handler. Routes backed by an
+// optional dependency are only registered when it is provided.
+type Option func(h *Handler)
+
+// WithCampaigns enables the campaign management endpoints.
+func WithCampaigns(uc port.CampaignUseCase) Option {
+	return func(h *Handler) { h.campaigns = uc }
+}
+
+// WithCreatives enables the creative management endpoints. They are nested
+// under the campaign routes and require WithCampaigns as well.
+func WithCreatives(uc port.CreativeUseCase) Option {
+	return func(h *Handler) { h.creatives = uc }
+}
+
+// WithOpenRTB enables the OpenRTB bidder endpoint. Bids are priced in
+// currency, which has minorUnits budget units per unit (100 for cents).
+func WithOpenRTB(currency string, minorUnits float64) Option {
+	return func(h *Handler) { h.rtbCurrency, h.rtbMinorUnits = currency, minorUnits }
+}
+
+// WithExport enables the raw event export for clients presenting one of
+// apiKeys as a bearer token. Blank keys are ignored and the endpoint stays
+// disabled when no key is left.
+func WithExport(uc port.ExportUseCase, apiKeys []string) Option {
+	return func(h *Handler) {
+		h.export = uc
+		for _, key := range apiKeys {
+			if key = strings.TrimSpace(key); key != "" {
+				h.exportKeys = append(h.exportKeys, key)
+			}
+		}
+	}
+}
+
+// WithMetrics serves the Prometheus metrics handler at /metrics.
+func WithMetrics(metrics http.Handler) Option {
+	return func(h *Handler) { h.metrics = metrics }
+}
+
+// WithHealth serves the readiness probe at /readyz. The liveness probe
+// at /healthz is always served.
+func WithHealth(uc port.HealthUseCase) Option {
+	return func(h *Handler) { h.health = uc }
+}
+
+// WithTracing records a server span of every ad request with tp. Callers
+// may continue their trace through a W3C traceparent header.
+func WithTracing(tp trace.TracerProvider) Option {
+	return func(h *Handler) { h.tracer = tp.Tracer("mesa-ads") }
 }
 
 // NewHandler creates a handler with all routes configured. It accepts a
-// Service implementation and a logger. The returned Handler registers
-// handlers for each endpoint on a new chi.Router.
-func NewHandler(svc port.AdUseCase, logger *slog.Logger) *Handler {
-	h := &Handler{svc: svc, logger: logger}
+// Service implementation, a logger and optional dependencies. The returned
+// Handler registers handlers for each endpoint on a new chi.Router.
+func NewHandler(svc port.AdUseCase, logger *slog.Logger, opts ...Option) *Handler {
+	h := &Handler{svc: svc, logger: logger, tracer: noop.NewTracerProvider().Tracer("")}
+	for _, opt := range opts {
+		opt(h)
+	}
 	r := chi.NewRouter()
+	r.Get("/healthz", h.handleHealthz)
+	if h.health != nil {
+		r.Get("/readyz", h.handleReadyz)
+	}
+	if h.metrics != nil {
+		r.Handle("/metrics", h.metrics)
+	}
+
+	if h.rtbCurrency != "" {
+		r.Route("/openrtb", func(r chi.Router) {
+			r.Post("/bid", h.handleOpenRTBBid)
+			r.Get("/win/{token}", h.handleOpenRTBWin)
+			r.Get("/loss/{token}", h.handleOpenRTBLoss)
+			r.Get("/impression/{token}", h.handleOpenRTBImpression)
+		})
+	}
 
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Post("/ad/request", h.handleAdRequest)
+		r.Post("/ad/request", h.traced(h.handleAdRequest))
+		r.Get("/ad/vast", h.handleVASTRequest)
+		r.Get("/ad/impression/{token}", h.handleImpression)
 		r.Get("/ad/click/{token}", h.handleAdClick)
+		r.Get("/ad/track/{token}/{event}", h.handleTrackEvent)
 		r.Get("/stats/overview", h.handleStatsOverview)
+		r.Get("/stats/timeseries", h.handleStatsTimeSeries)
+
+		if h.export != nil && len(h.exportKeys) > 0 {
+			r.With(requireAPIKey(h.exportKeys)).Get("/export/events", h.handleExportEvents)
+		}
+
+		if h.campaigns != nil {
+			r.Route("/campaigns", func(r chi.Router) {
+				r.Get("/", h.handleListCampaigns)
+				r.Post("/", h.handleCreateCampaign)
+				r.Route("/{id}", func(r chi.Router) {
+					r.Get("/", h.handleGetCampaign)
+					r.Put("/", h.handleUpdateCampaign)
+					r.Post("/status", h.handleChangeStatus)
+					r.Get("/transitions", h.handleListTransitions)
+					r.Post("/pause", h.handlePauseCampaign)
+					r.Post("/resume", h.handleResumeCampaign)
+					r.Post("/archive", h.handleArchiveCampaign)
+					r.Get("/targeting", h.handleGetTargeting)
+					r.Put("/targeting", h.handlePutTargeting)
+
+					if h.creatives != nil {
+						r.Route("/creatives", func(r chi.Router) {
+							r.Get("/", h.handleListCreatives)
+							r.Post("/", h.handleCreateCreative)
+							r.Get("/{creativeID}", h.handleGetCreative)
+							r.Put("/{creativeID}", h.handleUpdateCreative)
+							r.Delete("/{creativeID}", h.handleDeleteCreative)
+						})
+					}
+				})
+			})
+		}
 	})
 	h.router = r
 	return h
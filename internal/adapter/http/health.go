@@ -0,0 +1,55 @@
+package httpadapter
+
+import (
+	"net/http"
+)
+
+// checkJSON is the outcome of one readiness check.
+type checkJSON struct {
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// readinessJSON is the body of /readyz. Status is ok, failing or
+// draining; checks are omitted while draining.
+type readinessJSON struct {
+	Status string               `json:"status"`
+	Checks map[string]checkJSON `json:"checks,omitempty"`
+}
+
+// handleHealthz reports that the process is alive and serving HTTP. It
+// does not look at dependencies, so an orchestrator does not restart the
+// service while the database is down.
+func (h *Handler) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	h.writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz runs the readiness checks. It responds 200 when every check
+// passed and 503 when one failed or the service is shutting down.
+func (h *Handler) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.health.Readiness(r.Context())
+	resp := readinessJSON{Status: "ok"}
+	switch {
+	case readiness.Draining:
+		resp.Status = "draining"
+	case !readiness.Ready():
+		resp.Status = "failing"
+	}
+	if len(readiness.Checks) > 0 {
+		resp.Checks = make(map[string]checkJSON, len(readiness.Checks))
+	}
+	for _, c := range readiness.Checks {
+		check := checkJSON{Status: "ok", DurationMS: float64(c.Duration.Microseconds()) / 1000}
+		if c.Err != nil {
+			check.Status, check.Error = "failing", c.Err.Error()
+		}
+		resp.Checks[c.Name] = check
+	}
+	status := http.StatusOK
+	if !readiness.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeJSON(w, status, resp)
+}
@@ -0,0 +1,78 @@
+package httpadapter
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestHealthz checks that liveness does not depend on readiness checks.
+func TestHealthz(t *testing.T) {
+	router := NewHandler(mocks.NewMockAdUseCase(t), slog.Default()).Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("readyz without WithHealth: status = %d, want 404", rec.Code)
+	}
+}
+
+// TestReadyz checks the status code and JSON detail of the readiness
+// probe.
+func TestReadyz(t *testing.T) {
+	health := mocks.NewMockHealthUseCase(t)
+	health.EXPECT().Readiness(mock.Anything).Return(port.Readiness{Checks: []port.CheckResult{
+		{Name: "database", Duration: 1500 * time.Microsecond},
+		{Name: "migrations"},
+	}}).Once()
+	health.EXPECT().Readiness(mock.Anything).Return(port.Readiness{Checks: []port.CheckResult{
+		{Name: "database"},
+		{Name: "migrations", Err: errors.New("schema version 11, want 12")},
+	}}).Once()
+	health.EXPECT().Readiness(mock.Anything).Return(port.Readiness{Draining: true}).Once()
+	router := NewHandler(mocks.NewMockAdUseCase(t), slog.Default(), WithHealth(health)).Router()
+
+	for _, want := range []struct {
+		code   int
+		body   readinessJSON
+		checks int
+	}{
+		{http.StatusOK, readinessJSON{Status: "ok", Checks: map[string]checkJSON{
+			"database": {Status: "ok", DurationMS: 1.5}}}, 2},
+		{http.StatusServiceUnavailable, readinessJSON{Status: "failing", Checks: map[string]checkJSON{
+			"migrations": {Status: "failing", Error: "schema version 11, want 12"}}}, 2},
+		{http.StatusServiceUnavailable, readinessJSON{Status: "draining"}, 0},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		if rec.Code != want.code {
+			t.Errorf("%s: status = %d, want %d", want.body.Status, rec.Code, want.code)
+		}
+		var got readinessJSON
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != want.body.Status || len(got.Checks) != want.checks {
+			t.Errorf("body = %+v, want status %s with %d checks", got, want.body.Status, want.checks)
+		}
+		for name, check := range want.body.Checks {
+			if got.Checks[name] != check {
+				t.Errorf("%s: check %s = %+v, want %+v", want.body.Status, name, got.Checks[name], check)
+			}
+		}
+	}
+}
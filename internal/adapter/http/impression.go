@@ -0,0 +1,20 @@
+package httpadapter
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// handleImpression confirms that the ad reserved under the {token} path
+// parameter was shown and bills it. It answers HTTP 204 so it can serve as
+// an impression pixel. Forged or expired tokens result in HTTP 403,
+// unknown tokens and expired reservations in HTTP 404. Repeated beacons
+// are accepted and billed once.
+func (h *Handler) handleImpression(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.ConfirmImpression(r.Context(), chi.URLParam(r, "token")); err != nil {
+		h.writeError(w, "confirm impression", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,35 @@
+package httpadapter
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestImpressionStatus checks the status codes of the impression pixel.
+func TestImpressionStatus(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().ConfirmImpression(mock.Anything, "tok").Return(nil)
+	svc.EXPECT().ConfirmImpression(mock.Anything, "gone").Return(port.ErrNotFound)
+	svc.EXPECT().ConfirmImpression(mock.Anything, "forged").Return(domain.ErrInvalidToken)
+	router := NewHandler(svc, slog.Default()).Router()
+
+	for path, want := range map[string]int{
+		"/api/v1/ad/impression/tok":    http.StatusNoContent,
+		"/api/v1/ad/impression/gone":   http.StatusNotFound,
+		"/api/v1/ad/impression/forged": http.StatusForbidden,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, rec.Code, want)
+		}
+	}
+}
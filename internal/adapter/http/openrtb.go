@@ -0,0 +1,309 @@
+package httpadapter
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"mesa-ads/internal/core/domain"
+)
+
+const openRTBVersion = "2.6"
+
+// openRTBNoBidInvalid is the OpenRTB no-bid reason for invalid requests.
+const openRTBNoBidInvalid = 2
+
+// openRTBAuctionPrice is the macro the exchange replaces with the clearing
+// price of its auction in win and billing notice URLs.
+const openRTBAuctionPrice = "${AUCTION_PRICE}"
+
+// OpenRTB start delays with a special meaning; positive values are
+// mid-roll offsets in seconds.
+const (
+	startDelayGenericMidRoll  = -1
+	startDelayGenericPostRoll = -2
+)
+
+// bidRequest is the subset of an OpenRTB 2.6 BidRequest the bidder reads.
+type bidRequest struct {
+	ID     string     `json:"id"`
+	Imp    []bidImp   `json:"imp"`
+	Site   *rtbSite   `json:"site,omitempty"`
+	App    *rtbSite   `json:"app,omitempty"`
+	Device *rtbDevice `json:"device,omitempty"`
+	User   *rtbUser   `json:"user,omitempty"`
+	Cur    []string   `json:"cur,omitempty"`
+}
+
+type bidImp struct {
+	ID          string    `json:"id"`
+	Video       *rtbVideo `json:"video,omitempty"`
+	BidFloor    float64   `json:"bidfloor,omitempty"`
+	BidFloorCur string    `json:"bidfloorcur,omitempty"`
+}
+
+type rtbVideo struct {
+	StartDelay *int `json:"startdelay,omitempty"`
+}
+
+// rtbSite covers both site and app objects, which share the fields used.
+type rtbSite struct {
+	Cat     []string    `json:"cat,omitempty"`
+	Content *rtbContent `json:"content,omitempty"`
+}
+
+type rtbContent struct {
+	Cat      []string `json:"cat,omitempty"`
+	Language string   `json:"language,omitempty"`
+}
+
+type rtbDevice struct {
+	Geo      *rtbGeo `json:"geo,omitempty"`
+	Language string  `json:"language,omitempty"`
+}
+
+type rtbGeo struct {
+	Country string `json:"country,omitempty"`
+}
+
+type rtbUser struct {
+	ID       string   `json:"id,omitempty"`
+	Keywords string   `json:"keywords,omitempty"`
+	KwArray  []string `json:"kwarray,omitempty"`
+}
+
+// bidResponse is an OpenRTB 2.6 BidResponse with a single seat.
+type bidResponse struct {
+	ID      string    `json:"id"`
+	SeatBid []seatBid `json:"seatbid,omitempty"`
+	Cur     string    `json:"cur,omitempty"`
+	NBR     *int      `json:"nbr,omitempty"`
+}
+
+type seatBid struct {
+	Bid  []rtbBid `json:"bid"`
+	Seat string   `json:"seat,omitempty"`
+}
+
+type rtbBid struct {
+	ID    string  `json:"id"`
+	ImpID string  `json:"impid"`
+	Price float64 `json:"price"`
+	AdM   string  `json:"adm"`
+	NURL  string  `json:"nurl"`
+	BURL  string  `json:"burl"`
+	LURL  string  `json:"lurl"`
+	CID   string  `json:"cid"`
+	CrID  string  `json:"crid"`
+}
+
+// handleOpenRTBBid answers an OpenRTB 2.6 bid request. Each video
+// impression runs through the regular ad selection with the impression's
+// bid floor; matching ads are bid at their clearing price with VAST markup.
+// Bidding only reserves the budget: the win (nurl) and billing (burl)
+// notices bill the impression at the exchange price, the loss notice (lurl)
+// releases the reservation. The notice URLs carry a notice token, which
+// the markup does not, so players cannot set the price. Reservations of
+// bids that are not sent are released right away. Requests without bids
+// get HTTP 204, malformed requests HTTP 400.
+func (h *Handler) handleOpenRTBBid(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Openrtb-Version", openRTBVersion)
+
+	var req bidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" || len(req.Imp) == 0 {
+		nbr := openRTBNoBidInvalid
+		h.writeJSON(w, http.StatusBadRequest, bidResponse{ID: req.ID, NBR: &nbr})
+		return
+	}
+	if len(req.Cur) > 0 && !slices.Contains(req.Cur, h.rtbCurrency) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	base := baseURL(r)
+	var (
+		bids []rtbBid
+		// tokens of the reservations behind bids
+		tokens []string
+	)
+	for _, imp := range req.Imp {
+		if imp.Video == nil || (imp.BidFloorCur != "" && imp.BidFloorCur != h.rtbCurrency) {
+			continue
+		}
+		ad, err := h.svc.RequestAd(r.Context(), h.openRTBUserContext(&req, &imp))
+		if err != nil {
+			h.logger.Error("openrtb bid error", slog.Any("error", err))
+			for _, token := range tokens {
+				h.releaseBid(r.Context(), token)
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if ad == nil {
+			continue
+		}
+		notice, err := h.svc.NoticeToken(ad.Token)
+		if err != nil {
+			// without a notice token the reservation cannot be released
+			// early; it expires on its own
+			h.logger.Error("openrtb notice token error", slog.Any("error", err))
+			continue
+		}
+		// the notices bill the impression at the exchange price, so the
+		// markup only carries a pixel that does not bill
+		ad.ImpressionURL = "/openrtb/impression/" + ad.Token
+		adm, err := xml.Marshal(newVASTDocument(ad, base))
+		if err != nil {
+			h.logger.Error("encode vast error", slog.Any("error", err))
+			h.releaseBid(r.Context(), notice)
+			continue
+		}
+		win := resolveURL(base, "/openrtb/win/"+notice) + "?price=" + openRTBAuctionPrice
+		tokens = append(tokens, notice)
+		bids = append(bids, rtbBid{
+			ID:    ad.ServingID,
+			ImpID: imp.ID,
+			Price: ad.Price / h.rtbMinorUnits,
+			AdM:   xml.Header + string(adm),
+			NURL:  win,
+			BURL:  win,
+			LURL:  resolveURL(base, "/openrtb/loss/"+notice),
+			CID:   strconv.FormatInt(ad.CampaignID, 10),
+			CrID:  strconv.FormatInt(ad.CreativeID, 10),
+		})
+	}
+	if len(bids) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, bidResponse{
+		ID:      req.ID,
+		SeatBid: []seatBid{{Bid: bids, Seat: vastAdSystem}},
+		Cur:     h.rtbCurrency,
+	})
+}
+
+// releaseBid returns the budget reserved for a bid that is not sent.
+// Failures are logged: the reservation expires on its own.
+func (h *Handler) releaseBid(ctx context.Context, token string) {
+	if err := h.svc.ReleaseImpression(ctx, token); err != nil {
+		h.logger.Error("release bid error", slog.Any("error", err))
+	}
+}
+
+// handleOpenRTBWin bills the bid identified by the notice token in the
+// {token} path parameter at the clearing price the exchange substituted
+// for ${AUCTION_PRICE} in the price query parameter, in currency units per
+// thousand impressions, but never above the bid price. It serves both the
+// win and the billing notice; the impression is settled once. Without a
+// usable price the bid price is billed. It answers like the impression
+// pixel.
+func (h *Handler) handleOpenRTBWin(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	var err error
+	price, perr := strconv.ParseFloat(r.URL.Query().Get("price"), 64)
+	if perr == nil && price >= 0 && !math.IsInf(price, 0) {
+		err = h.svc.SettleImpression(r.Context(), token, int64(math.Round(price*h.rtbMinorUnits)))
+	} else {
+		err = h.svc.ConfirmImpression(r.Context(), token)
+	}
+	if err != nil {
+		h.writeError(w, "openrtb win notice", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleOpenRTBLoss releases the budget reserved for the lost bid
+// identified by the notice token in the {token} path parameter. Forged,
+// expired and ad tokens result in HTTP 403, bids without a pending
+// reservation in HTTP 404.
+func (h *Handler) handleOpenRTBLoss(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.ReleaseImpression(r.Context(), chi.URLParam(r, "token")); err != nil {
+		h.writeError(w, "openrtb loss notice", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleOpenRTBImpression is the impression pixel of the bid markup, which
+// VAST requires. It answers HTTP 204 without billing: the exchange notices
+// bill bids.
+func (h *Handler) handleOpenRTBImpression(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// openRTBUserContext maps a bid request impression onto the user context
+// of the ad selection.
+func (h *Handler) openRTBUserContext(req *bidRequest, imp *bidImp) domain.UserContext {
+	userCtx := domain.UserContext{
+		Placement: openRTBPlacement(imp.Video.StartDelay),
+		Floor:     int64(math.Ceil(imp.BidFloor * h.rtbMinorUnits)),
+	}
+	if u := req.User; u != nil {
+		userCtx.UserID = u.ID
+		userCtx.Interests = append(userCtx.Interests, u.KwArray...)
+		for _, kw := range strings.Split(u.Keywords, ",") {
+			if kw = strings.TrimSpace(kw); kw != "" {
+				userCtx.Interests = append(userCtx.Interests, kw)
+			}
+		}
+	}
+	if d := req.Device; d != nil {
+		userCtx.Language = d.Language
+		if d.Geo != nil {
+			userCtx.Geo = openRTBCountry(d.Geo.Country)
+		}
+	}
+	for _, src := range []*rtbSite{req.Site, req.App} {
+		if src == nil {
+			continue
+		}
+		if c := src.Content; c != nil {
+			if userCtx.Language == "" {
+				userCtx.Language = c.Language
+			}
+			if len(c.Cat) > 0 {
+				userCtx.Category = c.Cat[0]
+			}
+		}
+		if userCtx.Category == "" && len(src.Cat) > 0 {
+			userCtx.Category = src.Cat[0]
+		}
+	}
+	return userCtx
+}
+
+// openRTBPlacement derives the placement from the video start delay. An
+// unknown start delay matches creatives of any placement.
+func openRTBPlacement(startDelay *int) string {
+	switch {
+	case startDelay == nil:
+		return ""
+	case *startDelay == 0:
+		return domain.PlacementPreRoll
+	case *startDelay > 0 || *startDelay == startDelayGenericMidRoll:
+		return domain.PlacementMidRoll
+	case *startDelay == startDelayGenericPostRoll:
+		return domain.PlacementPostRoll
+	}
+	return ""
+}
+
+// openRTBCountry converts the ISO 3166-1 alpha-3 country of OpenRTB to the
+// alpha-2 code used in targeting. Unknown codes are passed through.
+func openRTBCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if c, ok := domain.CountryFromAlpha3(country); ok {
+		return c
+	}
+	return country
+}
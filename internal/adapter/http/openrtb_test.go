@@ -0,0 +1,146 @@
+package httpadapter
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+const testBidRequest = `{
+  "id": "req-1",
+  "imp": [
+    {"id": "1", "video": {"mimes": ["video/mp4"], "startdelay": 0}, "bidfloor": 2.5, "bidfloorcur": "USD"},
+    {"id": "2", "banner": {"w": 300, "h": 250}}
+  ],
+  "site": {"cat": ["IAB1"], "content": {"cat": ["IAB1-6"], "language": "de"}},
+  "device": {"geo": {"country": "DEU"}, "language": "ru"},
+  "user": {"id": "u1", "keywords": "music, games"},
+  "cur": ["USD"]
+}`
+
+// TestOpenRTBBid checks the mapping of a bid request and the returned bid.
+func TestOpenRTBBid(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	ad := testAd()
+	ad.CampaignID, ad.Price = 3, 1234
+	svc.EXPECT().
+		RequestAd(mock.Anything, domain.UserContext{
+			UserID:    "u1",
+			Language:  "ru",
+			Geo:       "DE",
+			Category:  "IAB1-6",
+			Interests: []string{"music", "games"},
+			Placement: domain.PlacementPreRoll,
+			Floor:     250,
+		}).
+		Return(ad, nil).
+		Once()
+	svc.EXPECT().NoticeToken("tok").Return("ntok", nil).Once()
+	router := NewHandler(svc, slog.Default(), WithOpenRTB("USD", 100)).Router()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/openrtb/bid", strings.NewReader(testBidRequest))
+	req.Host = "ads.example.com"
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Openrtb-Version") != "2.6" {
+		t.Fatalf("status = %d, headers = %v", rec.Code, rec.Header())
+	}
+	var resp bidResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.ID != "req-1" || resp.Cur != "USD" || len(resp.SeatBid) != 1 || len(resp.SeatBid[0].Bid) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	bid := resp.SeatBid[0].Bid[0]
+	if bid.ImpID != "1" || bid.Price != 12.34 || bid.CID != "3" || bid.CrID != "7" {
+		t.Fatalf("unexpected bid: %+v", bid)
+	}
+	if bid.NURL != "http://ads.example.com/openrtb/win/ntok?price=${AUCTION_PRICE}" || bid.BURL != bid.NURL ||
+		bid.LURL != "http://ads.example.com/openrtb/loss/ntok" {
+		t.Fatalf("unexpected notices: %q, %q, %q", bid.NURL, bid.BURL, bid.LURL)
+	}
+	if !strings.Contains(bid.AdM, `<VAST version="4.2">`) {
+		t.Fatalf("expected VAST markup, got %q", bid.AdM)
+	}
+	// the win notice bills the impression, not the player, and the markup
+	// never carries the notice token
+	if !strings.Contains(bid.AdM, "http://ads.example.com/openrtb/impression/tok") ||
+		strings.Contains(bid.AdM, "ntok") {
+		t.Fatalf("expected a non-billing impression pixel in markup, got %q", bid.AdM)
+	}
+}
+
+// TestOpenRTBReleaseOnError checks that reservations of bids that are not
+// sent are released.
+func TestOpenRTBReleaseOnError(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(testAd(), nil).Once()
+	svc.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+	svc.EXPECT().NoticeToken("tok").Return("ntok", nil).Once()
+	svc.EXPECT().ReleaseImpression(mock.Anything, "ntok").Return(nil).Once()
+	router := NewHandler(svc, slog.Default(), WithOpenRTB("USD", 100)).Router()
+
+	body := `{"id": "r", "imp": [{"id": "1", "video": {}}, {"id": "2", "video": {}}]}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/openrtb/bid", strings.NewReader(body)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+}
+
+// TestOpenRTBNotices checks that win notices bill the exchange price and
+// loss notices release the reservation.
+func TestOpenRTBNotices(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().SettleImpression(mock.Anything, "tok", int64(812)).Return(nil).Once()
+	svc.EXPECT().ConfirmImpression(mock.Anything, "tok").Return(nil).Once()
+	svc.EXPECT().ReleaseImpression(mock.Anything, "tok").Return(nil).Once()
+	svc.EXPECT().ReleaseImpression(mock.Anything, "billed").Return(port.ErrNotFound).Once()
+	router := NewHandler(svc, slog.Default(), WithOpenRTB("USD", 100)).Router()
+
+	for target, want := range map[string]int{
+		"/openrtb/win/tok?price=8.12":             http.StatusNoContent,
+		"/openrtb/win/tok?price=${AUCTION_PRICE}": http.StatusNoContent,
+		"/openrtb/loss/tok":                       http.StatusNoContent,
+		"/openrtb/loss/billed":                    http.StatusNotFound,
+		"/openrtb/impression/tok":                 http.StatusNoContent,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
+
+// TestOpenRTBNoBid checks that unusable requests are not bid on.
+func TestOpenRTBNoBid(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(nil, nil).Once()
+	router := NewHandler(svc, slog.Default(), WithOpenRTB("USD", 100)).Router()
+
+	for body, want := range map[string]int{
+		`{"id": "r", "imp": [{"id": "1", "video": {}}]}`:                 http.StatusNoContent,
+		`{"id": "r", "imp": [{"id": "1", "video": {}}], "cur": ["EUR"]}`: http.StatusNoContent,
+		`{"id": "r", "imp": []}`:                                         http.StatusBadRequest,
+		`{`:                                                              http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/openrtb/bid", strings.NewReader(body)))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d", body, rec.Code, want)
+		}
+	}
+}
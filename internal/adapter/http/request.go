@@ -11,8 +11,9 @@ import (
 // handleAdRequest processes an ad request and returns a creative. The
 // request body is decoded into a model.UserContext. On success it
 // returns a JSON representation of the selected creative. If no creative
-// is available it returns HTTP 204 No Content. Any internal error
-// results in HTTP 500. Parsing errors produce HTTP 400.
+// is available it returns HTTP 204 No Content. Clients accepting XML get
+// a VAST document instead, which is empty when no creative is available.
+// Any internal error results in HTTP 500. Parsing errors produce HTTP 400.
 func (h *Handler) handleAdRequest(w http.ResponseWriter, r *http.Request) {
 	var userCtx domain.UserContext
 	if err := json.NewDecoder(r.Body).Decode(&userCtx); err != nil {
@@ -25,6 +26,10 @@ func (h *Handler) handleAdRequest(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
+	if wantsVAST(r) {
+		h.writeVAST(w, r, resp)
+		return
+	}
 	if resp == nil {
 		w.WriteHeader(http.StatusNoContent)
 		return
@@ -0,0 +1,70 @@
+package httpadapter
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// writeJSON encodes v as the JSON response body with the given status code.
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("encode response error", slog.Any("error", err))
+	}
+}
+
+// writeError maps use case errors to HTTP status codes. Validation errors
+// are reported to the client verbatim, unknown errors are logged and hidden
+// behind a generic HTTP 500.
+func (h *Handler) writeError(w http.ResponseWriter, op string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, port.ErrNotFound):
+		http.Error(w, "not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidToken):
+		http.Error(w, "invalid token", http.StatusForbidden)
+	case errors.Is(err, domain.ErrInvalidTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, port.ErrConflict):
+		http.Error(w, "conflict", http.StatusConflict)
+	default:
+		h.logger.Error(op+" error", slog.Any("error", err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+// pathID parses a positive int64 path parameter bound by the router.
+func pathID(r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, name), 10, 64)
+	return id, err == nil && id > 0
+}
+
+// queryPage reads optional `limit` and `offset` query parameters.
+func queryPage(r *http.Request) (port.Page, bool) {
+	var (
+		q    = r.URL.Query()
+		page port.Page
+		err  error
+	)
+	if v := q.Get("limit"); v != "" {
+		if page.Limit, err = strconv.Atoi(v); err != nil || page.Limit < 0 {
+			return page, false
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if page.Offset, err = strconv.Atoi(v); err != nil || page.Offset < 0 {
+			return page, false
+		}
+	}
+	return page.Normalize(), true
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -13,11 +14,79 @@ import (
 // handleStatsOverview returns aggregated statistics for campaigns over a
 // specified period. It accepts optional `from`, `to` (RFC3339 timestamps) and
 // `campaign_id` query parameters. If no period is provided, it defaults to
-// the last 24 hours. Invalid parameters result in HTTP 400. Internal errors
-// produce HTTP 500. On success it writes a JSON representation of the stats.
+// the last 24 hours. With `group_by` (campaign, creative, placement, language
+// or geo) it returns a row per dimension value instead of a single total.
+// Invalid parameters result in HTTP 400. Internal errors produce HTTP 500.
+// On success it writes a JSON representation of the stats.
 func (h *Handler) handleStatsOverview(w http.ResponseWriter, r *http.Request) {
+	req, msg := statsReqFromQuery(r.URL.Query())
+	if msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+	if groupBy := r.URL.Query().Get("group_by"); groupBy != "" {
+		rows, err := h.svc.GetGroupedStats(r.Context(), port.GroupedStatsReq{
+			StatsReq: req,
+			GroupBy:  port.StatsDimension(groupBy),
+		})
+		if err != nil {
+			h.writeError(w, "grouped stats", err)
+			return
+		}
+		h.writeJSON(w, http.StatusOK, rows)
+		return
+	}
+
+	stats, err := h.svc.GetStats(r.Context(), req)
+	if err != nil {
+		h.logger.Error("stats error", slog.Any("error", err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(stats); err != nil {
+		h.logger.Error("encode response error", slog.Any("error", err))
+	}
+}
+
+// handleStatsTimeSeries returns statistics split into hourly or daily
+// buckets. Besides the parameters of handleStatsOverview it accepts
+// `granularity` (`hour` by default or `day`) and `tz`, an IANA time zone
+// (UTC by default) whose wall clock the buckets follow. Every bucket of the
+// period is returned, empty ones with zero counts.
+func (h *Handler) handleStatsTimeSeries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	statsReq, msg := statsReqFromQuery(q)
+	if msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+	req := port.TimeSeriesReq{StatsReq: statsReq, Granularity: port.GranularityHour, Location: time.UTC}
+	if g := q.Get("granularity"); g != "" {
+		req.Granularity = port.Granularity(g)
+	}
+	if tz := q.Get("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			http.Error(w, "invalid 'tz' time zone", http.StatusBadRequest)
+			return
+		}
+		req.Location = loc
+	}
+
+	buckets, err := h.svc.GetStatsTimeSeries(r.Context(), req)
+	if err != nil {
+		h.writeError(w, "stats time series", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, buckets)
+}
+
+// statsReqFromQuery reads the period and campaign of a stats request. It
+// returns a non-empty message describing the first invalid parameter.
+func statsReqFromQuery(q url.Values) (port.StatsReq, string) {
 	var (
-		q       = r.URL.Query()
 		fromStr = q.Get("from")
 		toStr   = q.Get("to")
 		req     port.StatsReq
@@ -27,8 +96,7 @@ func (h *Handler) handleStatsOverview(w http.ResponseWriter, r *http.Request) {
 	if fromStr != "" {
 		req.From, err = time.Parse(time.RFC3339, fromStr)
 		if err != nil {
-			http.Error(w, "invalid 'from' timestamp", http.StatusBadRequest)
-			return
+			return req, "invalid 'from' timestamp"
 		}
 	} else {
 		req.From = time.Now().Add(-24 * time.Hour)
@@ -37,8 +105,7 @@ func (h *Handler) handleStatsOverview(w http.ResponseWriter, r *http.Request) {
 	if toStr != "" {
 		req.To, err = time.Parse(time.RFC3339, toStr)
 		if err != nil {
-			http.Error(w, "invalid 'to' timestamp", http.StatusBadRequest)
-			return
+			return req, "invalid 'to' timestamp"
 		}
 	} else {
 		req.To = time.Now()
@@ -47,21 +114,9 @@ func (h *Handler) handleStatsOverview(w http.ResponseWriter, r *http.Request) {
 	if cid := q.Get("campaign_id"); cid != "" {
 		id, err := strconv.ParseInt(cid, 10, 64)
 		if err != nil {
-			http.Error(w, "invalid campaign_id", http.StatusBadRequest)
-			return
+			return req, "invalid campaign_id"
 		}
 		req.CampaignID = &id
 	}
-
-	stats, err := h.svc.GetStats(r.Context(), req)
-	if err != nil {
-		h.logger.Error("stats error", slog.Any("error", err))
-		http.Error(w, "internal error", http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err = json.NewEncoder(w).Encode(stats); err != nil {
-		h.logger.Error("encode response error", slog.Any("error", err))
-	}
+	return req, ""
 }
@@ -0,0 +1,95 @@
+package httpadapter
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestStatsTimeSeries checks the query parameters and the bucket encoding.
+func TestStatsTimeSeries(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	campaignID := int64(3)
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().
+		GetStatsTimeSeries(mock.Anything, port.TimeSeriesReq{
+			StatsReq:    port.StatsReq{From: from, To: from.Add(time.Hour), CampaignID: &campaignID},
+			Granularity: port.GranularityDay,
+			Location:    time.UTC,
+		}).
+		Return([]port.StatsBucket{{Start: from, Impressions: 10, Clicks: 1, Cost: 25}}, nil).
+		Once()
+	svc.EXPECT().
+		GetStatsTimeSeries(mock.Anything, mock.MatchedBy(func(req port.TimeSeriesReq) bool {
+			return req.Granularity == "week"
+		})).
+		Return(nil, domain.ErrValidation).
+		Once()
+	router := NewHandler(svc, slog.Default()).Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/api/v1/stats/timeseries?granularity=day&tz=UTC&campaign_id=3&from=2025-06-01T00:00:00Z&to=2025-06-01T01:00:00Z", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var buckets []port.StatsBucket
+	if err := json.NewDecoder(rec.Body).Decode(&buckets); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(buckets) != 1 || !buckets[0].Start.Equal(from) || buckets[0].Impressions != 10 || buckets[0].Cost != 25 {
+		t.Fatalf("unexpected buckets: %+v", buckets)
+	}
+
+	for _, query := range []string{"?granularity=week", "?tz=Mars/Olympus", "?from=yesterday"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats/timeseries"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+// TestStatsGroupBy checks that group_by returns a row per dimension value.
+func TestStatsGroupBy(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().
+		GetGroupedStats(mock.Anything, mock.MatchedBy(func(req port.GroupedStatsReq) bool {
+			return req.GroupBy == port.DimensionGeo && req.CampaignID != nil && *req.CampaignID == 3
+		})).
+		Return([]port.StatsRow{{Value: "RU", Impressions: 1000, Clicks: 10, Cost: 2000, CTR: 0.01, ECPM: 2000, ECPC: 200}}, nil).
+		Once()
+	svc.EXPECT().
+		GetGroupedStats(mock.Anything, mock.MatchedBy(func(req port.GroupedStatsReq) bool { return req.GroupBy == "device" })).
+		Return(nil, domain.ErrValidation).
+		Once()
+	router := NewHandler(svc, slog.Default()).Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats/overview?group_by=geo&campaign_id=3", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var rows []port.StatsRow
+	if err := json.NewDecoder(rec.Body).Decode(&rows); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Value != "RU" || rows[0].ECPC != 200 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats/overview?group_by=device", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,56 @@
+package httpadapter
+
+import (
+	"io"
+	"net/http"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// maxTargetingBody limits the size of a targeting document.
+const maxTargetingBody = 1 << 20
+
+// handleGetTargeting returns the targeting of the campaign bound to the
+// {id} path parameter, or HTTP 404 when the campaign has none and is not
+// served.
+func (h *Handler) handleGetTargeting(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	t, err := h.campaigns.GetTargeting(r.Context(), id)
+	if err != nil {
+		h.writeError(w, "get targeting", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, t)
+}
+
+// handlePutTargeting replaces the targeting of a campaign. The body is a
+// targeting document with the languages, geos, categories, interests and
+// placements keys; unknown keys, unknown ISO language or country codes and
+// unknown placements result in HTTP 400.
+func (h *Handler) handlePutTargeting(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "id")
+	if !ok {
+		http.Error(w, "invalid campaign id", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxTargetingBody))
+	if err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	tgt, err := domain.ParseTargeting(body)
+	if err != nil {
+		h.writeError(w, "parse targeting", err)
+		return
+	}
+	stored, err := h.campaigns.SetTargeting(r.Context(), id, tgt)
+	if err != nil {
+		h.writeError(w, "set targeting", err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, stored)
+}
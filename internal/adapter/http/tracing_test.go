@@ -0,0 +1,78 @@
+package httpadapter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestAdRequestSpan checks the server span of an ad request: it continues
+// the caller's trace, is the parent of the use case call and carries the
+// response status.
+func TestAdRequestSpan(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	var inner trace.SpanContext
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().RequestAd(mock.Anything, domain.UserContext{UserID: "u1"}).RunAndReturn(
+		func(ctx context.Context, _ domain.UserContext) (*port.AdResponse, error) {
+			inner = trace.SpanContextFromContext(ctx)
+			return nil, nil
+		})
+	svc.EXPECT().RequestAd(mock.Anything, domain.UserContext{UserID: "u2"}).Return(nil, errors.New("db down"))
+	router := NewHandler(svc, slog.Default(), WithTracing(tp)).Router()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/ad/request", strings.NewReader(`{"UserID":"u1"}`))
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodPost, "/api/v1/ad/request", strings.NewReader(`{"UserID":"u2"}`)))
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "POST /api/v1/ad/request" || span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span = %q (%v), want server span POST /api/v1/ad/request", span.Name(), span.SpanKind())
+	}
+	if got := span.SpanContext().TraceID().String(); got != traceID {
+		t.Errorf("trace id = %s, want the caller's %s", got, traceID)
+	}
+	if !span.Parent().IsRemote() {
+		t.Error("span parent is not the remote caller")
+	}
+	if inner.SpanID() != span.SpanContext().SpanID() {
+		t.Error("use case did not run inside the server span")
+	}
+	wantStatus := map[int]codes.Code{http.StatusNoContent: codes.Unset, http.StatusInternalServerError: codes.Error}
+	for i, s := range spans {
+		var status int64
+		for _, kv := range s.Attributes() {
+			if kv.Key == attribute.Key("http.response.status_code") {
+				status = kv.Value.AsInt64()
+			}
+		}
+		want, ok := wantStatus[int(status)]
+		if !ok || s.Status().Code != want {
+			t.Errorf("span %d: status code %d with span status %v", i, status, s.Status())
+		}
+		delete(wantStatus, int(status))
+	}
+}
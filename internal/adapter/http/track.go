@@ -0,0 +1,24 @@
+package httpadapter
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// handleTrackEvent records a player tracking event. It expects {token} and
+// {event} path parameters bound by the router and answers HTTP 204 so it
+// can serve as a tracking pixel. Unknown events result in HTTP 400, forged
+// or expired tokens in HTTP 403 and unknown tokens in HTTP 404. Repeated
+// events are accepted and ignored.
+func (h *Handler) handleTrackEvent(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	event := domain.TrackingEvent(chi.URLParam(r, "event"))
+	if err := h.svc.TrackEvent(r.Context(), token, event); err != nil {
+		h.writeError(w, "track event", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,35 @@
+package httpadapter
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestTrackEventStatus checks the status codes of the tracking pixel.
+func TestTrackEventStatus(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().TrackEvent(mock.Anything, "tok", domain.EventMidpoint).Return(nil)
+	svc.EXPECT().TrackEvent(mock.Anything, "tok", domain.TrackingEvent("rewind")).Return(domain.ErrValidation)
+	svc.EXPECT().TrackEvent(mock.Anything, "gone", domain.EventStart).Return(port.ErrNotFound)
+	router := NewHandler(svc, slog.Default()).Router()
+
+	for path, want := range map[string]int{
+		"/api/v1/ad/track/tok/midpoint": http.StatusNoContent,
+		"/api/v1/ad/track/tok/rewind":   http.StatusBadRequest,
+		"/api/v1/ad/track/gone/start":   http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package httpadapter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+const (
+	vastVersion     = "4.2"
+	vastContentType = "application/xml; charset=utf-8"
+	vastAdSystem    = "mesa-ads"
+
+	// Creatives do not store their dimensions, so media files advertise a
+	// nominal 16:9 size. Players use it only to pick among media files.
+	vastMediaWidth  = 1280
+	vastMediaHeight = 720
+)
+
+// vastDocument is the root of a VAST 4.2 response. A document without ads
+// tells the player that no ad is available.
+type vastDocument struct {
+	XMLName xml.Name `xml:"VAST"`
+	Version string   `xml:"version,attr"`
+	Ads     []vastAd `xml:"Ad"`
+}
+
+type vastAd struct {
+	ID     string     `xml:"id,attr"`
+	InLine vastInLine `xml:"InLine"`
+}
+
+type vastInLine struct {
+	AdSystem    string         `xml:"AdSystem"`
+	AdTitle     string         `xml:"AdTitle"`
+	AdServingID string         `xml:"AdServingId"`
+	Impressions []vastURI      `xml:"Impression"`
+	Creatives   []vastCreative `xml:"Creatives>Creative"`
+}
+
+type vastCreative struct {
+	ID     string     `xml:"id,attr"`
+	AdID   string     `xml:"adId,attr"`
+	Linear vastLinear `xml:"Linear"`
+}
+
+type vastLinear struct {
+	Duration       string          `xml:"Duration"`
+	TrackingEvents []vastTracking  `xml:"TrackingEvents>Tracking"`
+	ClickThrough   vastURI         `xml:"VideoClicks>ClickThrough"`
+	MediaFiles     []vastMediaFile `xml:"MediaFiles>MediaFile"`
+}
+
+type vastURI struct {
+	ID  string `xml:"id,attr"`
+	URL string `xml:",cdata"`
+}
+
+type vastTracking struct {
+	Event string `xml:"event,attr"`
+	URL   string `xml:",cdata"`
+}
+
+type vastMediaFile struct {
+	Delivery string `xml:"delivery,attr"`
+	Type     string `xml:"type,attr"`
+	Width    int    `xml:"width,attr"`
+	Height   int    `xml:"height,attr"`
+	URL      string `xml:",cdata"`
+}
+
+// newVASTDocument renders the selected ad as an inline linear VAST ad.
+// Relative URLs of the response are resolved against base. A nil ad
+// yields an empty document.
+func newVASTDocument(ad *port.AdResponse, base *url.URL) vastDocument {
+	doc := vastDocument{Version: vastVersion}
+	if ad == nil {
+		return doc
+	}
+
+	id := fmt.Sprint(ad.CreativeID)
+	linear := vastLinear{
+		Duration:     vastDuration(ad.Duration),
+		ClickThrough: vastURI{ID: vastAdSystem, URL: resolveURL(base, ad.ClickURL)},
+		MediaFiles:   []vastMediaFile{newVASTMediaFile(ad.VideoURL)},
+	}
+	for _, event := range domain.TrackingEvents {
+		if u, ok := ad.TrackingURLs[event]; ok {
+			linear.TrackingEvents = append(linear.TrackingEvents,
+				vastTracking{Event: string(event), URL: resolveURL(base, u)})
+		}
+	}
+
+	doc.Ads = []vastAd{{
+		ID: id,
+		InLine: vastInLine{
+			AdSystem:    vastAdSystem,
+			AdTitle:     ad.Title,
+			AdServingID: ad.ServingID,
+			Creatives:   []vastCreative{{ID: id, AdID: id, Linear: linear}},
+		},
+	}}
+	if ad.ImpressionURL != "" {
+		doc.Ads[0].InLine.Impressions = []vastURI{{ID: vastAdSystem, URL: resolveURL(base, ad.ImpressionURL)}}
+	}
+	return doc
+}
+
+// newVASTMediaFile describes a video by the extension of its URL. HLS
+// playlists are streamed, everything else is downloaded progressively.
+func newVASTMediaFile(videoURL string) vastMediaFile {
+	f := vastMediaFile{
+		Delivery: "progressive",
+		Type:     "video/mp4",
+		Width:    vastMediaWidth,
+		Height:   vastMediaHeight,
+		URL:      videoURL,
+	}
+	ext := ""
+	if u, err := url.Parse(videoURL); err == nil {
+		ext = strings.ToLower(path.Ext(u.Path))
+	}
+	switch ext {
+	case ".m3u8":
+		f.Delivery, f.Type = "streaming", "application/x-mpegURL"
+	case ".webm":
+		f.Type = "video/webm"
+	case ".mov":
+		f.Type = "video/quicktime"
+	}
+	return f
+}
+
+// vastDuration formats seconds as the VAST HH:MM:SS.mmm time code.
+func vastDuration(seconds int) string {
+	return fmt.Sprintf("%02d:%02d:%02d.000", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// resolveURL resolves a service relative URL against base.
+func resolveURL(base *url.URL, ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// baseURL returns the externally visible root of the service as seen by
+// the client, honouring X-Forwarded-Proto set by a TLS terminating proxy.
+func baseURL(r *http.Request) *url.URL {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return &url.URL{Scheme: scheme, Host: r.Host}
+}
+
+// wantsVAST reports whether the client prefers an XML response.
+func wantsVAST(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/xml", "text/xml", "application/x-vast+xml":
+			return true
+		}
+	}
+	return false
+}
+
+// writeVAST writes ad as a VAST document, or an empty document for a nil
+// ad. Both are served with HTTP 200 as players expect.
+func (h *Handler) writeVAST(w http.ResponseWriter, r *http.Request, ad *port.AdResponse) {
+	w.Header().Set("Content-Type", vastContentType)
+	_, _ = w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(newVASTDocument(ad, baseURL(r))); err != nil {
+		h.logger.Error("encode vast error", slog.Any("error", err))
+	}
+}
+
+// handleVASTRequest serves an ad as VAST to players that can only issue GET
+// requests. The user context is read from the `userID`, `language`, `geo`,
+// `category`, `placement` and comma separated `interests` query
+// parameters. Internal errors result in HTTP 500.
+func (h *Handler) handleVASTRequest(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userCtx := domain.UserContext{
+		UserID:    q.Get("userID"),
+		Language:  q.Get("language"),
+		Geo:       q.Get("geo"),
+		Category:  q.Get("category"),
+		Placement: q.Get("placement"),
+	}
+	if v := q.Get("interests"); v != "" {
+		userCtx.Interests = strings.Split(v, ",")
+	}
+	resp, err := h.svc.RequestAd(r.Context(), userCtx)
+	if err != nil {
+		h.logger.Error("request ad error", slog.Any("error", err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	h.writeVAST(w, r, resp)
+}
@@ -0,0 +1,172 @@
+package httpadapter
+
+import (
+	"encoding/xml"
+	"flag"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testAd() *port.AdResponse {
+	return &port.AdResponse{
+		ServingID:     "tok",
+		Token:         "tok",
+		CreativeID:    7,
+		Title:         "Summer sale",
+		Duration:      75,
+		VideoURL:      "https://cdn.example.com/video/7.mp4",
+		ClickURL:      "/api/v1/ad/click/tok",
+		ImpressionURL: "/api/v1/ad/impression/tok",
+		TrackingURLs: map[domain.TrackingEvent]string{
+			domain.EventStart:         "/api/v1/ad/track/tok/start",
+			domain.EventFirstQuartile: "/api/v1/ad/track/tok/firstQuartile",
+			domain.EventMidpoint:      "/api/v1/ad/track/tok/midpoint",
+			domain.EventThirdQuartile: "/api/v1/ad/track/tok/thirdQuartile",
+			domain.EventComplete:      "/api/v1/ad/track/tok/complete",
+			domain.EventSkip:          "/api/v1/ad/track/tok/skip",
+			domain.EventPause:         "/api/v1/ad/track/tok/pause",
+			domain.EventMute:          "/api/v1/ad/track/tok/mute",
+			domain.EventUnmute:        "/api/v1/ad/track/tok/unmute",
+		},
+	}
+}
+
+// golden compares got with testdata/name, rewriting the file with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	file := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(file, got, 0o644); err != nil {
+			t.Fatalf("update golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("response differs from %s:\n%s", file, got)
+	}
+}
+
+// TestVASTResponse checks the VAST rendering of a selected ad and of a no-fill.
+func TestVASTResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		ad     *port.AdResponse
+		golden string
+	}{
+		{"ad", testAd(), "vast_ad.golden.xml"},
+		{"no fill", nil, "vast_empty.golden.xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewMockAdUseCase(t)
+			svc.EXPECT().
+				RequestAd(mock.Anything, domain.UserContext{
+					UserID: "u1", Geo: "RU", Placement: "pre-roll", Interests: []string{"music", "games"},
+				}).
+				Return(tt.ad, nil)
+
+			req := httptest.NewRequest(http.MethodGet,
+				"/api/v1/ad/vast?userID=u1&geo=RU&placement=pre-roll&interests=music,games", nil)
+			req.Host = "ads.example.com"
+			req.Header.Set("X-Forwarded-Proto", "https")
+			rec := httptest.NewRecorder()
+			NewHandler(svc, slog.Default()).Router().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+				t.Fatalf("Content-Type = %q", ct)
+			}
+			golden(t, tt.golden, rec.Body.Bytes())
+		})
+	}
+}
+
+// TestVASTStructure checks the elements players rely on.
+func TestVASTStructure(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "vast_ad.golden.xml"))
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	var doc vastDocument
+	if err = xml.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if doc.Version != "4.2" || len(doc.Ads) != 1 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	inline := doc.Ads[0].InLine
+	if inline.AdSystem == "" || inline.AdTitle == "" || inline.AdServingID == "" || len(inline.Impressions) != 1 {
+		t.Fatalf("missing required InLine elements: %+v", inline)
+	}
+	if len(inline.Creatives) != 1 {
+		t.Fatalf("expected one creative, got %d", len(inline.Creatives))
+	}
+	linear := inline.Creatives[0].Linear
+	if linear.Duration != "00:01:15.000" {
+		t.Fatalf("Duration = %q", linear.Duration)
+	}
+	if linear.ClickThrough.URL != "https://ads.example.com/api/v1/ad/click/tok" {
+		t.Fatalf("ClickThrough = %q", linear.ClickThrough.URL)
+	}
+	if len(linear.MediaFiles) != 1 || linear.MediaFiles[0].Type != "video/mp4" {
+		t.Fatalf("unexpected media files: %+v", linear.MediaFiles)
+	}
+	if len(linear.TrackingEvents) != len(domain.TrackingEvents) {
+		t.Fatalf("expected %d tracking events, got %d", len(domain.TrackingEvents), len(linear.TrackingEvents))
+	}
+}
+
+// TestAdRequestNegotiation ensures XML clients get VAST from the JSON endpoint.
+func TestAdRequestNegotiation(t *testing.T) {
+	svc := mocks.NewMockAdUseCase(t)
+	svc.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	router := NewHandler(svc, slog.Default()).Router()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/ad/request", strings.NewReader(`{"userID":"u1"}`))
+	req.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `<VAST version="4.2"></VAST>`) {
+		t.Fatalf("expected empty VAST, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/ad/request", strings.NewReader(`{"userID":"u1"}`))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 for JSON clients, got %d", rec.Code)
+	}
+}
+
+// TestVASTMediaFile checks media types derived from the video URL.
+func TestVASTMediaFile(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://cdn/v.mp4":         "video/mp4",
+		"https://cdn/v.WEBM?x=1":    "video/webm",
+		"https://cdn/live/v.m3u8":   "application/x-mpegURL",
+		"https://cdn/v":             "video/mp4",
+		"https://cdn/clip.mov#frag": "video/quicktime",
+	} {
+		if got := newVASTMediaFile(url).Type; got != want {
+			t.Errorf("%s: type = %q, want %q", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// AdRepository decorates a port.AdRepository with candidate, budget and
+// spend metrics. Methods without metrics are passed through.
+type AdRepository struct {
+	port.AdRepository
+
+	m *Metrics
+}
+
+// NewAdRepository wraps next with metrics.
+func NewAdRepository(next port.AdRepository, m *Metrics) *AdRepository {
+	return &AdRepository{AdRepository: next, m: m}
+}
+
+// GetEligibleCreatives observes the number of candidates.
+func (r *AdRepository) GetEligibleCreatives(ctx context.Context, user domain.UserContext) ([]port.CreativeCandidate, error) {
+	candidates, err := r.AdRepository.GetEligibleCreatives(ctx, user)
+	if err == nil {
+		r.m.candidates.Observe(float64(len(candidates)))
+	}
+	return candidates, err
+}
+
+// ReserveImpression counts reservations refused for insufficient budget;
+// RequestAd retries the auction without the campaign after each.
+func (r *AdRepository) ReserveImpression(
+	ctx context.Context,
+	imp domain.Impression,
+	cpmPrice int64,
+	expiresAt time.Time,
+) error {
+	err := r.AdRepository.ReserveImpression(ctx, imp, cpmPrice, expiresAt)
+	if errors.Is(err, port.ErrInsufficientBudget) {
+		r.m.budgetRetries.Inc()
+	}
+	return err
+}
+
+// CommitImpression adds the cost of a billed impression to the spend.
+func (r *AdRepository) CommitImpression(ctx context.Context, token string) (*domain.Impression, error) {
+	imp, err := r.AdRepository.CommitImpression(ctx, token)
+	if err == nil && imp != nil {
+		r.addSpend(imp.CampaignID, "impression", imp.Cost)
+	}
+	return imp, err
+}
+
+// SettleImpression adds the cost of an impression billed at an exchange
+// price to the spend.
+func (r *AdRepository) SettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	imp, err := r.AdRepository.SettleImpression(ctx, token, cpmPrice)
+	if err == nil && imp != nil {
+		r.addSpend(imp.CampaignID, "impression", imp.Cost)
+	}
+	return imp, err
+}
+
+// CreateClickAndDeductBudget counts stored clicks by outcome and adds the
+// price of new ones to the spend.
+func (r *AdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcPrice int64) error {
+	err := r.AdRepository.CreateClickAndDeductBudget(ctx, click, cpcPrice)
+	switch {
+	case err == nil:
+		r.m.clicks.WithLabelValues(clickSuccess).Inc()
+		r.addSpend(click.CampaignID, "click", max(cpcPrice, 0))
+	case errors.Is(err, port.ErrDuplicate):
+		r.m.clicks.WithLabelValues(clickDuplicate).Inc()
+	case errors.Is(err, port.ErrInsufficientBudget):
+		r.m.clicks.WithLabelValues(clickInsufficientBudget).Inc()
+	}
+	return err
+}
+
+func (r *AdRepository) addSpend(campaignID int64, event string, cost int64) {
+	r.m.spend.WithLabelValues(r.m.campaignLabel(campaignID), event).Add(float64(cost))
+}
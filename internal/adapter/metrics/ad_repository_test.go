@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestRepositoryMetrics checks candidate counts, budget retries, click
+// outcomes and spend.
+func TestRepositoryMetrics(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+	next := mocks.NewMockAdRepository(t)
+	next.EXPECT().GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return(make([]port.CreativeCandidate, 3), nil)
+	next.EXPECT().ReserveImpression(mock.Anything, mock.Anything, int64(100), mock.Anything).
+		Return(port.ErrInsufficientBudget).Once()
+	next.EXPECT().ReserveImpression(mock.Anything, mock.Anything, int64(90), mock.Anything).
+		Return(nil).Once()
+	next.EXPECT().CommitImpression(mock.Anything, "t1").
+		Return(&domain.Impression{CampaignID: 7, Cost: 2}, nil)
+	next.EXPECT().CommitImpression(mock.Anything, "t2").Return(nil, nil)
+	next.EXPECT().CreateClickAndDeductBudget(mock.Anything, mock.Anything, int64(150)).Return(nil).Once()
+	next.EXPECT().CreateClickAndDeductBudget(mock.Anything, mock.Anything, int64(150)).Return(port.ErrDuplicate).Once()
+	next.EXPECT().CreateClickAndDeductBudget(mock.Anything, mock.Anything, int64(150)).
+		Return(port.ErrInsufficientBudget).Once()
+	repo := NewAdRepository(next, m)
+	ctx := context.Background()
+
+	_, _ = repo.GetEligibleCreatives(ctx, domain.UserContext{})
+	_ = repo.ReserveImpression(ctx, domain.Impression{CampaignID: 7}, 100, time.Now())
+	_ = repo.ReserveImpression(ctx, domain.Impression{CampaignID: 8}, 90, time.Now())
+	_, _ = repo.CommitImpression(ctx, "t1")
+	_, _ = repo.CommitImpression(ctx, "t2")
+	for i := 0; i < 3; i++ {
+		_ = repo.CreateClickAndDeductBudget(ctx, domain.Click{CampaignID: 7}, 150)
+	}
+
+	if got := testutil.ToFloat64(m.budgetRetries); got != 1 {
+		t.Errorf("budget retries = %v, want 1", got)
+	}
+	for outcome, want := range map[string]float64{clickSuccess: 1, clickDuplicate: 1, clickInsufficientBudget: 1} {
+		if got := testutil.ToFloat64(m.clicks.WithLabelValues(outcome)); got != want {
+			t.Errorf("%s clicks = %v, want %v", outcome, got, want)
+		}
+	}
+	if got := testutil.ToFloat64(m.spend.WithLabelValues("7", "impression")); got != 2 {
+		t.Errorf("impression spend = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.spend.WithLabelValues("7", "click")); got != 150 {
+		t.Errorf("click spend = %v, want 150", got)
+	}
+
+	const want = `
+# HELP mesa_ads_ad_candidates Eligible creatives per ad request, before pacing and frequency capping.
+# TYPE mesa_ads_ad_candidates histogram
+mesa_ads_ad_candidates_bucket{le="0"} 0
+mesa_ads_ad_candidates_bucket{le="1"} 0
+mesa_ads_ad_candidates_bucket{le="2"} 0
+mesa_ads_ad_candidates_bucket{le="5"} 1
+mesa_ads_ad_candidates_bucket{le="10"} 1
+mesa_ads_ad_candidates_bucket{le="20"} 1
+mesa_ads_ad_candidates_bucket{le="50"} 1
+mesa_ads_ad_candidates_bucket{le="100"} 1
+mesa_ads_ad_candidates_bucket{le="200"} 1
+mesa_ads_ad_candidates_bucket{le="500"} 1
+mesa_ads_ad_candidates_bucket{le="1000"} 1
+mesa_ads_ad_candidates_bucket{le="+Inf"} 1
+mesa_ads_ad_candidates_sum 3
+mesa_ads_ad_candidates_count 1
+`
+	if err := testutil.CollectAndCompare(m.candidates, strings.NewReader(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSpendCampaignCap checks that campaigns beyond the label cap share
+// the "other" series while the capped ones keep theirs.
+func TestSpendCampaignCap(t *testing.T) {
+	m := New(prometheus.NewRegistry(), WithSpendCampaigns(2))
+	next := mocks.NewMockAdRepository(t)
+	next.EXPECT().CreateClickAndDeductBudget(mock.Anything, mock.Anything, int64(10)).Return(nil)
+	repo := NewAdRepository(next, m)
+	ctx := context.Background()
+
+	for _, id := range []int64{1, 2, 3, 4, 1, 3} {
+		_ = repo.CreateClickAndDeductBudget(ctx, domain.Click{CampaignID: id}, 10)
+	}
+
+	for label, want := range map[string]float64{"1": 20, "2": 10, spendOther: 30} {
+		if got := testutil.ToFloat64(m.spend.WithLabelValues(label, "click")); got != want {
+			t.Errorf("campaign %s spend = %v, want %v", label, got, want)
+		}
+	}
+	if got := testutil.CollectAndCount(m.spend); got != 3 {
+		t.Errorf("spend series = %d, want 3", got)
+	}
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// AdUseCase decorates a port.AdUseCase with request and click metrics.
+// Methods without metrics are passed through.
+type AdUseCase struct {
+	port.AdUseCase
+
+	m *Metrics
+}
+
+// NewAdUseCase wraps next with metrics.
+func NewAdUseCase(next port.AdUseCase, m *Metrics) *AdUseCase {
+	return &AdUseCase{AdUseCase: next, m: m}
+}
+
+// RequestAd counts the request by result and observes its latency.
+func (u *AdUseCase) RequestAd(ctx context.Context, user domain.UserContext) (*port.AdResponse, error) {
+	start := time.Now()
+	resp, err := u.AdUseCase.RequestAd(ctx, user)
+	u.m.selection.Observe(time.Since(start).Seconds())
+
+	result := "filled"
+	switch {
+	case err != nil:
+		result = "error"
+	case resp == nil:
+		result = "no_fill"
+	}
+	u.m.adRequests.WithLabelValues(result).Inc()
+	return resp, err
+}
+
+// RegisterClick counts clicks rejected before they are stored.
+func (u *AdUseCase) RegisterClick(ctx context.Context, token string) (string, error) {
+	url, err := u.AdUseCase.RegisterClick(ctx, token)
+	switch {
+	case err == nil, errors.Is(err, port.ErrInsufficientBudget):
+		// counted by the repository decorator
+	case errors.Is(err, domain.ErrInvalidToken):
+		u.m.clicks.WithLabelValues(clickInvalidToken).Inc()
+	case errors.Is(err, port.ErrNotFound):
+		u.m.clicks.WithLabelValues(clickUnknownToken).Inc()
+	default:
+		u.m.clicks.WithLabelValues(clickError).Inc()
+	}
+	return url, err
+}
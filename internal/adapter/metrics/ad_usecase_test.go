@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestRequestAdResults checks that ad requests are counted by result and
+// timed.
+func TestRequestAdResults(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+	next := mocks.NewMockAdUseCase(t)
+	next.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(&port.AdResponse{CreativeID: 1}, nil).Twice()
+	next.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(nil, nil).Once()
+	next.EXPECT().RequestAd(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+	svc := NewAdUseCase(next, m)
+
+	for i := 0; i < 4; i++ {
+		_, _ = svc.RequestAd(context.Background(), domain.UserContext{})
+	}
+	for result, want := range map[string]float64{"filled": 2, "no_fill": 1, "error": 1} {
+		if got := testutil.ToFloat64(m.adRequests.WithLabelValues(result)); got != want {
+			t.Errorf("%s requests = %v, want %v", result, got, want)
+		}
+	}
+	if n := testutil.CollectAndCount(m.selection); n != 1 {
+		t.Fatalf("selection histogram has %d series", n)
+	}
+}
+
+// TestRegisterClickRejections checks the click outcomes decided before a
+// click is stored and that stored clicks are left to the repository.
+func TestRegisterClickRejections(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+	next := mocks.NewMockAdUseCase(t)
+	next.EXPECT().RegisterClick(mock.Anything, "ok").Return("https://landing", nil)
+	next.EXPECT().RegisterClick(mock.Anything, "forged").Return("", domain.ErrInvalidToken)
+	next.EXPECT().RegisterClick(mock.Anything, "unknown").Return("", port.ErrNotFound)
+	next.EXPECT().RegisterClick(mock.Anything, "broke").Return("", port.ErrInsufficientBudget)
+	next.EXPECT().RegisterClick(mock.Anything, "failed").Return("", errors.New("db down"))
+	svc := NewAdUseCase(next, m)
+
+	for _, token := range []string{"ok", "forged", "unknown", "broke", "failed"} {
+		_, _ = svc.RegisterClick(context.Background(), token)
+	}
+	for outcome, want := range map[string]float64{
+		clickInvalidToken: 1, clickUnknownToken: 1, clickError: 1, clickSuccess: 0, clickInsufficientBudget: 0,
+	} {
+		if got := testutil.ToFloat64(m.clicks.WithLabelValues(outcome)); got != want {
+			t.Errorf("%s clicks = %v, want %v", outcome, got, want)
+		}
+	}
+}
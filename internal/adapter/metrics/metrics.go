@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "mesa_ads"
+
+// spendOther is the campaign_id label of the spend of campaigns beyond the
+// cap set by WithSpendCampaigns.
+const spendOther = "other"
+
+// defaultSpendCampaigns is the campaign_id label cap used without
+// WithSpendCampaigns.
+const defaultSpendCampaigns = 500
+
+// Click outcomes. Clicks reaching storage are counted by the repository
+// decorator, clicks rejected before by the use case decorator, so every
+// click is counted once.
+const (
+	clickSuccess            = "success"
+	clickDuplicate          = "duplicate"
+	clickInsufficientBudget = "insufficient_budget"
+	clickUnknownToken       = "unknown_token"
+	clickInvalidToken       = "invalid_token"
+	clickError              = "error"
+)
+
+// Metrics holds the Prometheus collectors of ad serving. They are filled by
+// the AdUseCase and AdRepository decorators.
+type Metrics struct {
+	adRequests    *prometheus.CounterVec
+	selection     prometheus.Histogram
+	candidates    prometheus.Histogram
+	budgetRetries prometheus.Counter
+	clicks        *prometheus.CounterVec
+	spend         *prometheus.CounterVec
+
+	spendCampaigns int
+	mu             sync.Mutex
+	spendLabels    map[int64]string
+}
+
+// Option configures Metrics.
+type Option func(m *Metrics)
+
+// WithSpendCampaigns caps the campaign_id label values of the spend
+// counter at n. The first n campaigns billed since the start get their own
+// series, the spend of later ones is counted under campaign_id "other", so
+// the number of series stays bounded however many campaigns are created.
+func WithSpendCampaigns(n int) Option {
+	return func(m *Metrics) { m.spendCampaigns = n }
+}
+
+// New creates the collectors and registers them with reg.
+func New(reg prometheus.Registerer, opts ...Option) *Metrics {
+	m := &Metrics{
+		spendCampaigns: defaultSpendCampaigns,
+		spendLabels:    make(map[int64]string),
+		adRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ad_requests_total",
+			Help:      "Ad requests by result: filled, no_fill or error.",
+		}, []string{"result"}),
+		selection: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "ad_selection_duration_seconds",
+			Help:      "Time to select an ad and reserve its budget.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}),
+		candidates: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "ad_candidates",
+			Help:      "Eligible creatives per ad request, before pacing and frequency capping.",
+			Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		}),
+		budgetRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ad_budget_retries_total",
+			Help:      "Auction winners dropped for insufficient budget, each rerunning the auction.",
+		}),
+		clicks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "clicks_total",
+			Help: "Clicks by outcome: success, duplicate, insufficient_budget, unknown_token, " +
+				"invalid_token or error.",
+		}, []string{"outcome"}),
+		spend: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "spend_total",
+			Help: "Billed budget in minor currency units by campaign and event: impression or click. " +
+				"Campaigns beyond the label cap are counted as \"other\".",
+		}, []string{"campaign_id", "event"}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	reg.MustRegister(m.adRequests, m.selection, m.candidates, m.budgetRetries, m.clicks, m.spend)
+	return m
+}
+
+// campaignLabel returns the campaign_id label of the spend of a campaign.
+func (m *Metrics) campaignLabel(campaignID int64) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if label, ok := m.spendLabels[campaignID]; ok {
+		return label
+	}
+	if len(m.spendLabels) >= m.spendCampaigns {
+		return spendOther
+	}
+	label := strconv.FormatInt(campaignID, 10)
+	m.spendLabels[campaignID] = label
+	return label
+}
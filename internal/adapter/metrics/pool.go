@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStats is the part of *pgxpool.Stat reported by PoolCollector.
+type PoolStats interface {
+	AcquireCount() int64
+	AcquireDuration() time.Duration
+	AcquiredConns() int32
+	CanceledAcquireCount() int64
+	EmptyAcquireCount() int64
+	IdleConns() int32
+	MaxConns() int32
+	NewConnsCount() int64
+	TotalConns() int32
+}
+
+// PoolCollector exports the statistics of a database connection pool. The
+// statistics are read on every scrape.
+type PoolCollector struct {
+	stat func() PoolStats
+
+	acquiredConns   *prometheus.Desc
+	idleConns       *prometheus.Desc
+	totalConns      *prometheus.Desc
+	maxConns        *prometheus.Desc
+	acquires        *prometheus.Desc
+	acquireSeconds  *prometheus.Desc
+	emptyAcquires   *prometheus.Desc
+	canceledAcquire *prometheus.Desc
+	newConns        *prometheus.Desc
+}
+
+// NewPoolCollector returns a collector reading pool statistics from stat.
+func NewPoolCollector(stat func() PoolStats) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &PoolCollector{
+		stat:            stat,
+		acquiredConns:   desc("acquired_conns", "Connections currently checked out of the pool."),
+		idleConns:       desc("idle_conns", "Idle connections in the pool."),
+		totalConns:      desc("total_conns", "Open connections in the pool."),
+		maxConns:        desc("max_conns", "Maximum size of the pool."),
+		acquires:        desc("acquires_total", "Successful connection acquisitions."),
+		acquireSeconds:  desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		emptyAcquires:   desc("empty_acquires_total", "Acquisitions that waited because the pool was empty."),
+		canceledAcquire: desc("canceled_acquires_total", "Acquisitions canceled by their context."),
+		newConns:        desc("new_conns_total", "Connections opened by the pool."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+	gauge := func(d *prometheus.Desc, v int32) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, float64(v))
+	}
+	counter := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+	}
+	gauge(c.acquiredConns, s.AcquiredConns())
+	gauge(c.idleConns, s.IdleConns())
+	gauge(c.totalConns, s.TotalConns())
+	gauge(c.maxConns, s.MaxConns())
+	counter(c.acquires, float64(s.AcquireCount()))
+	counter(c.acquireSeconds, s.AcquireDuration().Seconds())
+	counter(c.emptyAcquires, float64(s.EmptyAcquireCount()))
+	counter(c.canceledAcquire, float64(s.CanceledAcquireCount()))
+	counter(c.newConns, float64(s.NewConnsCount()))
+}
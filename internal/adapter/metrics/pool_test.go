@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type fakePoolStats struct{}
+
+func (fakePoolStats) AcquireCount() int64            { return 120 }
+func (fakePoolStats) AcquireDuration() time.Duration { return 1500 * time.Millisecond }
+func (fakePoolStats) AcquiredConns() int32           { return 3 }
+func (fakePoolStats) CanceledAcquireCount() int64    { return 1 }
+func (fakePoolStats) EmptyAcquireCount() int64       { return 4 }
+func (fakePoolStats) IdleConns() int32               { return 2 }
+func (fakePoolStats) MaxConns() int32                { return 10 }
+func (fakePoolStats) NewConnsCount() int64           { return 5 }
+func (fakePoolStats) TotalConns() int32              { return 5 }
+
+// TestPoolCollector checks that pool statistics are exported on scrape.
+func TestPoolCollector(t *testing.T) {
+	c := NewPoolCollector(func() PoolStats { return fakePoolStats{} })
+	const want = `
+# HELP mesa_ads_db_pool_acquire_duration_seconds_total Total time spent acquiring connections.
+# TYPE mesa_ads_db_pool_acquire_duration_seconds_total counter
+mesa_ads_db_pool_acquire_duration_seconds_total 1.5
+# HELP mesa_ads_db_pool_acquired_conns Connections currently checked out of the pool.
+# TYPE mesa_ads_db_pool_acquired_conns gauge
+mesa_ads_db_pool_acquired_conns 3
+# HELP mesa_ads_db_pool_max_conns Maximum size of the pool.
+# TYPE mesa_ads_db_pool_max_conns gauge
+mesa_ads_db_pool_max_conns 10
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(want),
+		"mesa_ads_db_pool_acquire_duration_seconds_total",
+		"mesa_ads_db_pool_acquired_conns",
+		"mesa_ads_db_pool_max_conns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := testutil.CollectAndCount(c); n != 9 {
+		t.Fatalf("collected %d metrics, want 9", n)
+	}
+	if problems, err := testutil.CollectAndLint(c); err != nil || len(problems) > 0 {
+		t.Fatalf("lint: %v %v", problems, err)
+	}
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewInvalidTargetingCounter exports how many campaign targeting documents
+// failed to parse during candidate selection. count is read on every scrape.
+func NewInvalidTargetingCounter(count func() int64) prometheus.CounterFunc {
+	return prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invalid_targeting_total",
+		Help:      "Campaign targeting documents that failed to parse; such campaigns are not served.",
+	}, func() float64 { return float64(count()) })
+}
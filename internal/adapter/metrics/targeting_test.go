@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestInvalidTargetingCounter checks that the counter reads the current
+// count on every scrape.
+func TestInvalidTargetingCounter(t *testing.T) {
+	var n int64 = 2
+	c := NewInvalidTargetingCounter(func() int64 { return n })
+	if got := testutil.ToFloat64(c); got != 2 {
+		t.Fatalf("got %v, want 2", got)
+	}
+	n = 5
+	if got := testutil.ToFloat64(c); got != 5 {
+		t.Fatalf("got %v, want 5", got)
+	}
+	if problems, err := testutil.CollectAndLint(c); err != nil || len(problems) > 0 {
+		t.Fatalf("lint: %v %v", problems, err)
+	}
+}
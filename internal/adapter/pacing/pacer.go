@@ -0,0 +1,45 @@
+package pacing
+
+import (
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// Pacer implements port.Pacer. For even paced campaigns it compares the
+// spend so far today with an ideal linear spend curve running from zero at
+// local midnight to the full daily budget at the next midnight. A campaign
+// that is further ahead of the curve than the tolerance sits out of
+// selection until the curve catches up. ASAP campaigns are never throttled.
+type Pacer struct {
+	loc       *time.Location
+	now       func() time.Time
+	tolerance float64
+}
+
+// NewPacer creates a pacer. Days start at midnight in loc, now is the
+// clock (time.Now in production) and tolerance is the share of the daily
+// budget a campaign may run ahead of the ideal curve, e.g. 0.05.
+func NewPacer(loc *time.Location, now func() time.Time, tolerance float64) *Pacer {
+	return &Pacer{loc: loc, now: now, tolerance: tolerance}
+}
+
+// Allow reports whether the campaign is on or behind its spend curve.
+func (p *Pacer) Allow(c domain.Campaign) bool {
+	if c.PacingMode == domain.PacingASAP || c.DailyBudget <= 0 {
+		return true
+	}
+	spent := float64(c.DailyBudget - c.RemainingDailyBudget)
+	allowed := float64(c.DailyBudget) * (p.dayProgress() + p.tolerance)
+	return spent <= allowed
+}
+
+// dayProgress returns the elapsed share of the current local day in
+// [0, 1). The day length is measured between two local midnights so days
+// with a DST shift are handled correctly.
+func (p *Pacer) dayProgress() float64 {
+	now := p.now().In(p.loc)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, p.loc)
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, p.loc)
+	return float64(now.Sub(start)) / float64(end.Sub(start))
+}
@@ -0,0 +1,50 @@
+package pacing
+
+import (
+	"testing"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// TestPacerAllow checks throttling against the ideal spend curve.
+func TestPacerAllow(t *testing.T) {
+	noon := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		mode      domain.PacingMode
+		daily     int64
+		remaining int64
+		at        time.Time
+		want      bool
+	}{
+		{"on curve at noon", domain.PacingEven, 1000, 500, noon, true},
+		{"behind curve", domain.PacingEven, 1000, 900, noon, true},
+		{"within tolerance", domain.PacingEven, 1000, 460, noon, true},
+		{"ahead of curve", domain.PacingEven, 1000, 300, noon, false},
+		{"early morning burst", domain.PacingEven, 1000, 800, noon.Add(-11 * time.Hour), false},
+		{"asap ignores curve", domain.PacingASAP, 1000, 1, noon.Add(-11 * time.Hour), true},
+		{"no daily budget", domain.PacingEven, 0, 0, noon, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPacer(time.UTC, func() time.Time { return tt.at }, 0.05)
+			c := domain.Campaign{PacingMode: tt.mode, DailyBudget: tt.daily, RemainingDailyBudget: tt.remaining}
+			if got := p.Allow(c); got != tt.want {
+				t.Fatalf("Allow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPacerTimezone checks that days start at local midnight.
+func TestPacerTimezone(t *testing.T) {
+	loc := time.FixedZone("UTC+6", 6*60*60)
+	// 18:00 UTC is already midnight in UTC+6, so nothing may be spent yet
+	at := time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)
+	p := NewPacer(loc, func() time.Time { return at }, 0)
+	c := domain.Campaign{PacingMode: domain.PacingEven, DailyBudget: 1000, RemainingDailyBudget: 990}
+	if p.Allow(c) {
+		t.Fatalf("expected campaign ahead of curve at local midnight to be throttled")
+	}
+}
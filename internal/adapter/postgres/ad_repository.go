@@ -4,26 +4,41 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
 	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"mesa-ads/internal/adapter/hll"
 	"mesa-ads/internal/core/domain"
 	"mesa-ads/internal/core/port"
 )
 
-const maxImpressionsPerUserPerCreative = 3
-
 // AdRepository implements port.AdRepository using pgxpool for PostgreSQL.
 type AdRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+
+	// invalidTargeting counts targeting documents that failed to parse
+	// during candidate selection.
+	invalidTargeting atomic.Int64
+}
+
+// NewAdRepository returns a new repository instance. The logger reports
+// data problems found while selecting candidates.
+func NewAdRepository(pool *pgxpool.Pool, logger *slog.Logger) *AdRepository {
+	return &AdRepository{pool: pool, logger: logger}
 }
 
-// NewAdRepository returns a new repository instance.
-func NewAdRepository(pool *pgxpool.Pool) *AdRepository {
-	return &AdRepository{pool: pool}
+// InvalidTargetingCount returns how many campaign targeting documents failed
+// to parse during candidate selection since the repository was created.
+// Such campaigns are skipped.
+func (r *AdRepository) InvalidTargetingCount() int64 {
+	return r.invalidTargeting.Load()
 }
 
 // GetEligibleCreatives returns creatives matching the user context.
@@ -31,6 +46,45 @@ func (r *AdRepository) GetEligibleCreatives(
 	ctx context.Context,
 	user domain.UserContext,
 ) ([]port.CreativeCandidate, error) {
+	candidates, err := r.ListActiveCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return slices.DeleteFunc(candidates, func(c port.CreativeCandidate) bool {
+		return !c.Campaign.Running(now) || !c.Target.Matches(user)
+	}), nil
+}
+
+// ListCampaignBudgets returns the remaining budgets of active campaigns that
+// have not ended yet.
+func (r *AdRepository) ListCampaignBudgets(ctx context.Context) ([]port.BudgetUpdate, error) {
+	rows, err := r.pool.Query(ctx, `
+        SELECT id, remaining_daily_budget, remaining_total_budget
+        FROM campaigns
+        WHERE status = 'active' AND end_date >= now()`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := make([]port.BudgetUpdate, 0)
+	for rows.Next() {
+		var u port.BudgetUpdate
+		if err = rows.Scan(&u.CampaignID, &u.RemainingDailyBudget, &u.RemainingTotalBudget); err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, u)
+	}
+	return budgets, rows.Err()
+}
+
+// ListActiveCandidates returns creatives of active campaigns that have not
+// ended yet and still have budget. Campaigns without targeting are not
+// served; campaigns whose targeting cannot be parsed are logged, counted and
+// skipped.
+func (r *AdRepository) ListActiveCandidates(ctx context.Context) ([]port.CreativeCandidate, error) {
 	query := `
         SELECT
             c.id,
@@ -44,6 +98,8 @@ func (r *AdRepository) GetEligibleCreatives(
             c.cpm_bid,
             c.cpc_bid,
             c.status,
+            c.pacing_mode,
+            c.frequency_caps,
             c.created_at,
             c.updated_at,
             cr.id,
@@ -55,6 +111,7 @@ func (r *AdRepository) GetEligibleCreatives(
             cr.language,
             cr.category,
             cr.placement,
+            cr.frequency_caps,
             cr.created_at,
             cr.updated_at,
             t.data
@@ -62,7 +119,7 @@ func (r *AdRepository) GetEligibleCreatives(
         JOIN campaigns c ON cr.campaign_id = c.id
         JOIN campaign_targeting t ON t.campaign_id = c.id
         WHERE c.status = 'active'
-          AND now() BETWEEN c.start_date AND c.end_date
+          AND c.end_date >= now()
           AND c.remaining_daily_budget > 0 AND c.remaining_total_budget > 0`
 
 	rows, err := r.pool.Query(ctx, query)
@@ -92,6 +149,8 @@ func (r *AdRepository) GetEligibleCreatives(
 			&camp.CPMBid,
 			&camp.CPCBid,
 			&camp.Status,
+			&camp.PacingMode,
+			&camp.FrequencyCaps,
 			&camp.CreatedAt,
 			&camp.UpdatedAt,
 			&cr.ID,
@@ -103,6 +162,7 @@ func (r *AdRepository) GetEligibleCreatives(
 			&cr.Language,
 			&cr.Category,
 			&cr.Placement,
+			&cr.FrequencyCaps,
 			&cr.CreatedAt,
 			&cr.UpdatedAt,
 			&targetingRaw,
@@ -112,56 +172,12 @@ func (r *AdRepository) GetEligibleCreatives(
 
 		var tgt domain.Targeting
 		if err = json.Unmarshal(targetingRaw, &tgt); err != nil {
+			r.invalidTargeting.Add(1)
+			r.logger.Error("invalid campaign targeting, campaign skipped",
+				slog.Int64("campaign_id", camp.ID), slog.Any("error", err))
 			continue
 		}
-
-		if len(tgt.Languages) > 0 && !slices.Contains(tgt.Languages, user.Language) {
-			continue
-		}
-		if len(tgt.Geos) > 0 && !slices.Contains(tgt.Geos, user.Geo) {
-			continue
-		}
-		if len(tgt.Categories) > 0 && !slices.Contains(tgt.Categories, user.Category) {
-			continue
-		}
-		if len(tgt.Placements) > 0 && !slices.Contains(tgt.Placements, user.Placement) {
-			continue
-		}
-		if len(tgt.Interests) > 0 {
-			match := false
-			for _, v := range tgt.Interests {
-				if slices.Contains(user.Interests, v) {
-					match = true
-					break
-				}
-			}
-			if !match {
-				continue
-			}
-		}
-
-		// ограничиваем повторы креатива для одного пользователя
-		if user.UserID != "" {
-			var cnt int
-			err = r.pool.QueryRow(
-				ctx,
-				`SELECT COUNT(*) 
-                   FROM impressions 
-                  WHERE creative_id = $1 
-                    AND user_id = $2 
-                    AND created_at > now() - INTERVAL '1 hour'`,
-				cr.ID,
-				user.UserID,
-			).Scan(&cnt)
-			if err != nil {
-				return nil, err
-			}
-
-			if cnt >= maxImpressionsPerUserPerCreative {
-				// пользователь уже достаточно часто видел этот ролик недавно
-				continue
-			}
-		}
+		tgt.Normalize()
 
 		candidates = append(candidates, port.CreativeCandidate{
 			Creative: cr,
@@ -177,8 +193,14 @@ func (r *AdRepository) GetEligibleCreatives(
 	return candidates, nil
 }
 
-// CreateImpressionAndDeductBudget inserts impression and deducts budget for CPM campaigns.
-func (r *AdRepository) CreateImpressionAndDeductBudget(ctx context.Context, imp domain.Impression, cpmBid int64) error {
+// ReserveImpression deducts the CPM budget and stores the impression as a
+// reservation until expiresAt.
+func (r *AdRepository) ReserveImpression(
+	ctx context.Context,
+	imp domain.Impression,
+	cpmPrice int64,
+	expiresAt time.Time,
+) error {
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
 	if err != nil {
 		return err
@@ -190,45 +212,198 @@ func (r *AdRepository) CreateImpressionAndDeductBudget(ctx context.Context, imp
 			_ = tx.Commit(ctx)
 		}
 	}()
+
+	cost := impressionCost(cpmPrice)
+	if err = deductBudget(ctx, tx, imp.CampaignID, cost); err != nil {
+		return err
+	}
+
+	const insertQuery = `INSERT INTO impression_reservations
+    (token, creative_id, campaign_id, user_id, cost, click_cost, language, geo, placement, created_at, expires_at)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err = tx.Exec(ctx, insertQuery, imp.Token, imp.CreativeID, imp.CampaignID, imp.UserID,
+		cost, imp.ClickCost, imp.Language, imp.Geo, imp.Placement, time.Now().UTC(), expiresAt.UTC())
+	return err
+}
+
+// CommitImpression moves an unexpired reservation into the impressions
+// table in a single statement. It returns nil, nil when there is no such
+// reservation.
+func (r *AdRepository) CommitImpression(ctx context.Context, token string) (*domain.Impression, error) {
+	const query = `WITH r AS (
+    DELETE FROM impression_reservations WHERE token = $1 AND expires_at > $2
+    RETURNING token, creative_id, campaign_id, user_id, cost, click_cost, language, geo, placement
+)
+INSERT INTO impressions
+    (token, creative_id, campaign_id, user_id, cost, click_cost, language, geo, placement, created_at)
+SELECT token, creative_id, campaign_id, user_id, cost, click_cost, language, geo, placement, $2 FROM r
+RETURNING ` + impressionColumns
+
+	imp, err := scanImpression(r.pool.QueryRow(ctx, query, token, time.Now().UTC()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// SettleImpression moves an unexpired reservation into the impressions
+// table at the impression's share of cpmPrice, capped by the reserved cost,
+// and returns the rest of the reservation to the campaign budgets in a
+// single statement. It returns nil, nil when there is no such reservation.
+func (r *AdRepository) SettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	const query = `WITH r AS (
+    DELETE FROM impression_reservations WHERE token = $1 AND expires_at > $2
+    RETURNING token, creative_id, campaign_id, user_id, LEAST(cost, $3) AS cost, cost - LEAST(cost, $3) AS refund,
+        click_cost, language, geo, placement
+), u AS (
+    UPDATE campaigns c SET
+    remaining_daily_budget = LEAST(c.remaining_daily_budget + r.refund, c.daily_budget),
+    remaining_total_budget = LEAST(c.remaining_total_budget + r.refund, c.total_budget)
+    FROM r WHERE c.id = r.campaign_id AND r.refund > 0
+)
+INSERT INTO impressions
+    (token, creative_id, campaign_id, user_id, cost, click_cost, language, geo, placement, created_at, settled)
+SELECT token, creative_id, campaign_id, user_id, cost, click_cost, language, geo, placement, $2, true FROM r
+RETURNING ` + impressionColumns
+
+	imp, err := scanImpression(r.pool.QueryRow(ctx, query, token, time.Now().UTC(), impressionCost(cpmPrice)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// ResettleImpression lowers the cost of an unsettled impression to its
+// share of cpmPrice, marks it settled and returns the difference to the
+// campaign budgets in a single statement. It returns nil, nil when there
+// is no such impression.
+func (r *AdRepository) ResettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	const query = `WITH old AS (
+    SELECT id, cost FROM impressions WHERE token = $1 AND NOT settled FOR UPDATE
+), i AS (
+    UPDATE impressions SET cost = LEAST(old.cost, $2), settled = true
+    FROM old WHERE impressions.id = old.id
+    RETURNING impressions.*, old.cost - impressions.cost AS refund
+), u AS (
+    UPDATE campaigns c SET
+    remaining_daily_budget = LEAST(c.remaining_daily_budget + i.refund, c.daily_budget),
+    remaining_total_budget = LEAST(c.remaining_total_budget + i.refund, c.total_budget)
+    FROM i WHERE c.id = i.campaign_id AND i.refund > 0
+)
+SELECT ` + impressionColumns + ` FROM i`
+
+	imp, err := scanImpression(r.pool.QueryRow(ctx, query, token, impressionCost(cpmPrice)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// ReleaseImpression deletes the reservation of token and returns its cost
+// to the campaign budgets, not above their configured limits.
+func (r *AdRepository) ReleaseImpression(ctx context.Context, token string) (bool, error) {
+	const query = `WITH r AS (
+    DELETE FROM impression_reservations WHERE token = $1 RETURNING campaign_id, cost
+)
+UPDATE campaigns c SET
+    remaining_daily_budget = LEAST(c.remaining_daily_budget + r.cost, c.daily_budget),
+    remaining_total_budget = LEAST(c.remaining_total_budget + r.cost, c.total_budget)
+FROM r WHERE c.id = r.campaign_id`
+
+	tag, err := r.pool.Exec(ctx, query, token)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReleaseExpiredReservations deletes reservations expired at now and
+// returns their cost to the campaign budgets. Budgets are not raised above
+// their configured limits, so a daily reset in between does not inflate
+// the daily budget.
+func (r *AdRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	const query = `WITH r AS (
+    DELETE FROM impression_reservations WHERE expires_at <= $1 RETURNING campaign_id, cost
+), s AS (
+    SELECT campaign_id, sum(cost) AS cost, count(*) AS n FROM r GROUP BY campaign_id
+), u AS (
+    UPDATE campaigns c SET
+    remaining_daily_budget = LEAST(c.remaining_daily_budget + s.cost, c.daily_budget),
+    remaining_total_budget = LEAST(c.remaining_total_budget + s.cost, c.total_budget)
+    FROM s WHERE c.id = s.campaign_id
+)
+SELECT COALESCE(sum(n), 0)::bigint FROM s`
+
+	var released int64
+	err := r.pool.QueryRow(ctx, query, now.UTC()).Scan(&released)
+	return released, err
+}
+
+// impressionColumns lists the impressions columns in the order expected by
+// scanImpression.
+const impressionColumns = `id, token, creative_id, campaign_id, user_id, cost, click_cost,
+language, geo, placement, created_at`
+
+// scanImpression scans a row selected with impressionColumns.
+func scanImpression(row pgx.Row) (*domain.Impression, error) {
+	var imp domain.Impression
+	err := row.Scan(&imp.ID, &imp.Token, &imp.CreativeID, &imp.CampaignID, &imp.UserID, &imp.Cost,
+		&imp.ClickCost, &imp.Language, &imp.Geo, &imp.Placement, &imp.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+// impressionCost is the budget share of a single impression at cpmPrice,
+// rounded up.
+func impressionCost(cpmPrice int64) int64 {
+	if cpmPrice <= 0 {
+		return 0
+	}
+	return (cpmPrice + 999) / 1000
+}
+
+// deductBudget locks the campaign row and deducts cost from its remaining
+// budgets, failing with port.ErrInsufficientBudget when either is short.
+func deductBudget(ctx context.Context, tx pgx.Tx, campaignID, cost int64) error {
 	const selectQuery = `SELECT remaining_daily_budget, remaining_total_budget FROM campaigns WHERE id = $1 FOR UPDATE`
 
 	var remainingDaily, remainingTotal int64
-	err = tx.QueryRow(ctx, selectQuery, imp.CampaignID).Scan(&remainingDaily, &remainingTotal)
+	err := tx.QueryRow(ctx, selectQuery, campaignID).Scan(&remainingDaily, &remainingTotal)
 	if err != nil {
 		return err
 	}
-	cost := int64(0)
-	if cpmBid > 0 {
-		cost = (cpmBid + 999) / 1000
+	if cost <= 0 {
+		return nil
 	}
-	if cost > 0 && (remainingDaily < cost || remainingTotal < cost) {
+	if remainingDaily < cost || remainingTotal < cost {
 		return port.ErrInsufficientBudget
 	}
-	if cost > 0 {
-		const updateQuery = `UPDATE campaigns SET
+
+	const updateQuery = `UPDATE campaigns SET
 remaining_daily_budget = remaining_daily_budget - $1,
 remaining_total_budget = remaining_total_budget - $1
 	WHERE id = $2`
-		_, err = tx.Exec(ctx, updateQuery, cost, imp.CampaignID)
-		if err != nil {
-			return err
-		}
-	}
-
-	const insertQuery = `INSERT INTO impressions
-    (token, creative_id, campaign_id, user_id, cost, created_at) VALUES ($1,$2,$3,$4,$5,$6)`
-
-	imp.Cost = cost
-	imp.CreatedAt = time.Now().UTC()
-	_, err = tx.Exec(ctx, insertQuery, imp.Token, imp.CreativeID,
-		imp.CampaignID, imp.UserID, imp.Cost, imp.CreatedAt)
+	_, err = tx.Exec(ctx, updateQuery, cost, campaignID)
 	return err
 }
 
 // CreateClickAndDeductBudget inserts click event and deducts budget for CPC campaigns.
 // Operation is idempotent by token: repeated calls with the same token do not
-// create a new click and do not charge the budget again.
-func (r *AdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcBid int64) (err error) {
+// create a new click and do not charge the budget again; they result in
+// port.ErrDuplicate.
+func (r *AdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcPrice int64) (err error) {
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
 	if err != nil {
 		return err
@@ -241,7 +416,7 @@ func (r *AdRepository) CreateClickAndDeductBudget(ctx context.Context, click dom
 		}
 	}()
 
-	cost := cpcBid
+	cost := cpcPrice
 
 	const (
 		insertQuery = `
@@ -256,7 +431,7 @@ FROM campaigns WHERE id = $1 FOR UPDATE`
 		click.Cost = 0
 		click.CreatedAt = time.Now().UTC()
 
-		_, err = tx.Exec(ctx, insertQuery,
+		tag, err := tx.Exec(ctx, insertQuery,
 			click.Token,
 			click.ImpressionID,
 			click.CreativeID,
@@ -265,6 +440,9 @@ FROM campaigns WHERE id = $1 FOR UPDATE`
 			click.Cost,
 			click.CreatedAt,
 		)
+		if err == nil && tag.RowsAffected() == 0 {
+			return port.ErrDuplicate
+		}
 		return err
 	}
 
@@ -301,9 +479,9 @@ FROM campaigns WHERE id = $1 FOR UPDATE`
 	}
 
 	// Если строка не вставлена — это повторный клик с тем же токеном.
-	// Считаем это идемпотентным вызовом: бюджет не списываем.
+	// Бюджет не списываем, use case считает такой клик успешным.
 	if tag.RowsAffected() == 0 {
-		return nil
+		return port.ErrDuplicate
 	}
 
 	const updateQuery = `UPDATE campaigns SET
@@ -319,89 +497,333 @@ WHERE id = $2`
 	return err
 }
 
-// GetStats returns aggregated events for campaigns.
+// CreatePlaybackEvent inserts a tracking event unless the token already
+// has one of the same type.
+func (r *AdRepository) CreatePlaybackEvent(ctx context.Context, e domain.PlaybackEvent) error {
+	const query = `INSERT INTO tracking_events
+    (token, impression_id, creative_id, campaign_id, event, created_at) VALUES ($1,$2,$3,$4,$5,$6)
+ON CONFLICT (token, event) DO NOTHING`
+
+	_, err := r.pool.Exec(ctx, query, e.Token, e.ImpressionID, e.CreativeID,
+		e.CampaignID, e.Event, time.Now().UTC())
+	return err
+}
+
+// GetStats returns aggregated events for campaigns. Whole hours covered
+// by the rollups are read from stats_hourly, stats_hourly_events and
+// stats_hourly_reach, the partial hours at the edges of the period from the
+// raw event tables.
 func (r *AdRepository) GetStats(ctx context.Context, req port.StatsReq) (*port.StatsResp, error) {
-	args := []interface{}{req.From, req.To}
-	whereClause := "WHERE created_at >= $1 AND created_at <= $2"
+	lo, hi, err := r.rollupSplit(ctx, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	args := []interface{}{req.From.UTC(), req.To.UTC(), lo, hi}
+	rawWhere := "WHERE created_at >= $1 AND created_at <= $2 AND (created_at < $3 OR created_at >= $4)"
+	rollupWhere := "WHERE hour >= $3 AND hour < $4"
 
 	if req.CampaignID != nil {
-		whereClause += " AND campaign_id = $3"
+		rawWhere += " AND campaign_id = $5"
+		rollupWhere += " AND campaign_id = $5"
 		args = append(args, *req.CampaignID)
 	}
 
-	impQuery := `SELECT COALESCE(count(*),0), COALESCE(sum(cost),0) FROM impressions ` + whereClause
-	var impCount, impCost int64
-	err := r.pool.QueryRow(ctx, impQuery, args...).Scan(&impCount, &impCost)
+	query := `SELECT COALESCE(sum(impressions),0)::bigint, COALESCE(sum(clicks),0)::bigint, COALESCE(sum(cost),0)::bigint
+FROM (
+    SELECT impressions, clicks, cost FROM stats_hourly ` + rollupWhere + `
+    UNION ALL
+    SELECT count(*), 0, COALESCE(sum(cost),0) FROM impressions ` + rawWhere + `
+    UNION ALL
+    SELECT 0, count(*), COALESCE(sum(cost),0) FROM clicks ` + rawWhere + `
+) s`
+	var stats port.StatsResp
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&stats.Impressions, &stats.Clicks, &stats.Cost)
 	if err != nil {
 		return nil, err
 	}
 
-	clickQuery := `SELECT COALESCE(count(*),0), COALESCE(sum(cost),0) FROM clicks ` + whereClause
-	var clickCount, clickCost int64
-	err = r.pool.QueryRow(ctx, clickQuery, args...).Scan(&clickCount, &clickCost)
+	eventQuery := `SELECT event, sum(events)::bigint FROM (
+    SELECT event, events FROM stats_hourly_events ` + rollupWhere + `
+    UNION ALL
+    SELECT event, count(*) FROM tracking_events ` + rawWhere + ` GROUP BY event
+) s GROUP BY event`
+	rows, err := r.pool.Query(ctx, eventQuery, args...)
 	if err != nil {
 		return nil, err
 	}
-	return &port.StatsResp{
-		Impressions: impCount,
-		Clicks:      clickCount,
-		Cost:        impCost + clickCost,
-	}, nil
+	defer rows.Close()
+
+	stats.Events = make(map[domain.TrackingEvent]int64)
+	for rows.Next() {
+		var (
+			event domain.TrackingEvent
+			count int64
+		)
+		if err = rows.Scan(&event, &count); err != nil {
+			return nil, err
+		}
+		stats.Events[event] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if stats.Reach, err = r.reach(ctx, rollupWhere, rawWhere, args); err != nil {
+		return nil, err
+	}
+	stats.Reach = min(stats.Reach, stats.Impressions)
+	return &stats, nil
+}
+
+// reach estimates the distinct users who saw an impression by merging the
+// hourly sketches of the rolled up hours with a sketch of the raw edges.
+// The registers of the edges are computed in SQL, so neither part reads
+// more than a sketch per campaign hour and one row per register.
+func (r *AdRepository) reach(ctx context.Context, rollupWhere, rawWhere string, args []interface{}) (int64, error) {
+	query := `SELECT sketch, 0, 0 FROM stats_hourly_reach ` + rollupWhere + `
+UNION ALL
+SELECT NULL::bytea, register, max(rank) FROM (SELECT ` + userRegister + ` ` + rawWhere + `) e GROUP BY register`
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	sketch := hll.New()
+	for rows.Next() {
+		var (
+			data           []byte
+			register, rank int32
+		)
+		if err = rows.Scan(&data, &register, &rank); err != nil {
+			return 0, err
+		}
+		if data == nil {
+			sketch.AddRegister(int(register), uint8(rank))
+			continue
+		}
+		var hourly hll.Sketch
+		if err = hourly.UnmarshalBinary(data); err != nil {
+			return 0, err
+		}
+		sketch.Merge(&hourly)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+	return int64(sketch.Estimate()), nil
+}
+
+// GetStatsTimeSeries returns impressions, clicks and cost per bucket.
+// Buckets are computed by date_trunc in the requested time zone; event
+// times are stored in UTC. Rolled up hours are bucketed by their start,
+// which is exact unless the zone is offset from UTC by a fraction of an
+// hour; such zones are answered from the raw tables only.
+func (r *AdRepository) GetStatsTimeSeries(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error) {
+	lo, hi, err := r.rollupSplit(ctx, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if !wholeHourOffsets(req.Location, lo, hi) {
+		hi = lo
+	}
+	args := []interface{}{string(req.Granularity), req.Location.String(), req.From.UTC(), req.To.UTC(), lo, hi}
+	rawWhere := "WHERE created_at >= $3 AND created_at <= $4 AND (created_at < $5 OR created_at >= $6)"
+	rollupWhere := "WHERE hour >= $5 AND hour < $6"
+
+	if req.CampaignID != nil {
+		rawWhere += " AND campaign_id = $7"
+		rollupWhere += " AND campaign_id = $7"
+		args = append(args, *req.CampaignID)
+	}
+
+	const bucket = `date_trunc($1, created_at AT TIME ZONE 'UTC', $2)`
+	query := `SELECT bucket, sum(impressions)::bigint, sum(clicks)::bigint, sum(cost)::bigint FROM (
+    SELECT date_trunc($1, hour AT TIME ZONE 'UTC', $2) AS bucket, impressions, clicks, cost
+    FROM stats_hourly ` + rollupWhere + `
+    UNION ALL
+    SELECT ` + bucket + `, count(*), 0, COALESCE(sum(cost),0)
+    FROM impressions ` + rawWhere + ` GROUP BY 1
+    UNION ALL
+    SELECT ` + bucket + `, 0, count(*), COALESCE(sum(cost),0)
+    FROM clicks ` + rawWhere + ` GROUP BY 1
+) b GROUP BY bucket ORDER BY bucket`
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []port.StatsBucket
+	for rows.Next() {
+		var b port.StatsBucket
+		if err = rows.Scan(&b.Start, &b.Impressions, &b.Clicks, &b.Cost); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+// rollupSplit returns the whole hours [lo, hi) of the period [from, to]
+// that are covered by the rollups. Events outside them are read from the
+// raw tables; lo equals hi when the rollups cover no hour of the period.
+func (r *AdRepository) rollupSplit(ctx context.Context, from, to time.Time) (lo, hi time.Time, err error) {
+	var rolledFrom, rolledUntil time.Time
+	err = r.pool.QueryRow(ctx, `SELECT rolled_from, rolled_until FROM stats_rollup_state`).
+		Scan(&rolledFrom, &rolledUntil)
+	if err != nil {
+		return lo, hi, err
+	}
+	lo, hi = coveredSpan(from, to, rolledFrom, rolledUntil)
+	return lo, hi, nil
+}
+
+// coveredSpan returns the whole hours of [from, to] within the rolled up
+// range [rolledFrom, rolledUntil).
+func coveredSpan(from, to, rolledFrom, rolledUntil time.Time) (lo, hi time.Time) {
+	lo, hi = hourSpan(from, to)
+	if lo.Before(rolledFrom) {
+		lo = rolledFrom.UTC()
+	}
+	if hi.After(rolledUntil) {
+		hi = rolledUntil.UTC()
+	}
+	if !lo.Before(hi) {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// hourSpan returns the whole UTC hours [lo, hi) within [from, to].
+func hourSpan(from, to time.Time) (lo, hi time.Time) {
+	lo = from.UTC().Truncate(time.Hour)
+	if lo.Before(from) {
+		lo = lo.Add(time.Hour)
+	}
+	return lo, to.UTC().Truncate(time.Hour)
+}
+
+// wholeHourOffsets reports whether loc is offset from UTC by whole hours
+// during all of [from, to), so hourly rollups fall into a single bucket.
+func wholeHourOffsets(loc *time.Location, from, to time.Time) bool {
+	for t := from.In(loc); t.Before(to); {
+		if _, offset := t.Zone(); offset%3600 != 0 {
+			return false
+		}
+		_, end := t.ZoneBounds()
+		if end.IsZero() {
+			break
+		}
+		t = end
+	}
+	return true
+}
+
+// statsGroupKeys maps a dimension to its key expressions over impressions
+// (i), clicks (c) and stats_hourly. Clicks inherit the request context of
+// their impression. Dimensions without a rollup key are read from the raw
+// tables only.
+var statsGroupKeys = map[port.StatsDimension]struct{ impression, click, rollup string }{
+	port.DimensionCampaign:  {"i.campaign_id::text", "c.campaign_id::text", "campaign_id::text"},
+	port.DimensionCreative:  {"i.creative_id::text", "c.creative_id::text", "creative_id::text"},
+	port.DimensionPlacement: {"i.placement", "COALESCE(i.placement, '')", ""},
+	port.DimensionLanguage:  {"i.language", "COALESCE(i.language, '')", ""},
+	port.DimensionGeo:       {"i.geo", "COALESCE(i.geo, '')", ""},
+}
+
+// GetGroupedStats returns impressions, clicks and cost per dimension value.
+// Campaigns and creatives are read like GetStats, from stats_hourly for the
+// rolled up hours and from the raw tables for the rest of the period.
+func (r *AdRepository) GetGroupedStats(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error) {
+	keys, ok := statsGroupKeys[req.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown dimension %q", domain.ErrValidation, req.GroupBy)
+	}
+	from, to := req.From.UTC(), req.To.UTC()
+	lo, hi := from, from
+	if keys.rollup != "" {
+		var err error
+		if lo, hi, err = r.rollupSplit(ctx, from, to); err != nil {
+			return nil, err
+		}
+	}
+	args := []interface{}{from, to, lo, hi}
+	impWhere := "WHERE i.created_at >= $1 AND i.created_at <= $2 AND (i.created_at < $3 OR i.created_at >= $4)"
+	clickWhere := "WHERE c.created_at >= $1 AND c.created_at <= $2 AND (c.created_at < $3 OR c.created_at >= $4)"
+	rollupWhere := "WHERE hour >= $3 AND hour < $4"
+
+	if req.CampaignID != nil {
+		impWhere += " AND i.campaign_id = $5"
+		clickWhere += " AND c.campaign_id = $5"
+		rollupWhere += " AND campaign_id = $5"
+		args = append(args, *req.CampaignID)
+	}
+
+	query := `SELECT key, sum(impressions)::bigint, sum(clicks)::bigint, sum(cost)::bigint FROM (
+    SELECT ` + keys.impression + ` AS key, count(*) AS impressions, 0 AS clicks, COALESCE(sum(i.cost),0) AS cost
+    FROM impressions i ` + impWhere + ` GROUP BY 1
+    UNION ALL
+    SELECT ` + keys.click + `, 0, count(*), COALESCE(sum(c.cost),0)
+    FROM clicks c LEFT JOIN impressions i ON i.id = c.impression_id ` + clickWhere + ` GROUP BY 1`
+	if keys.rollup != "" {
+		query += `
+    UNION ALL
+    SELECT ` + keys.rollup + `, impressions, clicks, cost FROM stats_hourly ` + rollupWhere
+	}
+	query += `
+) s GROUP BY key ORDER BY 4 DESC, 1`
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []port.StatsRow
+	for rows.Next() {
+		var row port.StatsRow
+		if err = rows.Scan(&row.Value, &row.Impressions, &row.Clicks, &row.Cost); err != nil {
+			return nil, err
+		}
+		stats = append(stats, row)
+	}
+	return stats, rows.Err()
 }
 
 // FindImpressionByToken returns impression by token.
 func (r *AdRepository) FindImpressionByToken(ctx context.Context, token string) (*domain.Impression, error) {
-	const query = `SELECT
-id, token, creative_id, campaign_id, user_id, cost, created_at
-FROM impressions WHERE token = $1`
-	var imp domain.Impression
-	err := r.pool.QueryRow(ctx, query, token).Scan(
-		&imp.ID, &imp.Token, &imp.CreativeID, &imp.CampaignID, &imp.UserID, &imp.Cost, &imp.CreatedAt,
-	)
+	imp, err := scanImpression(r.pool.QueryRow(ctx, `SELECT `+impressionColumns+` FROM impressions WHERE token = $1`, token))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &imp, nil
+	return imp, nil
 }
 
 // GetCreative returns a creative by id.
 func (r *AdRepository) GetCreative(ctx context.Context, id int64) (*domain.Creative, error) {
-	const query = `SELECT id, campaign_id, title, video_url, landing_url,
-duration, language, category, placement, created_at, updated_at FROM creatives WHERE id = $1`
-
-	var cr domain.Creative
-	err := r.pool.QueryRow(ctx, query, id).
-		Scan(&cr.ID, &cr.CampaignID, &cr.Title, &cr.VideoURL, &cr.LandingURL,
-			&cr.Duration, &cr.Language, &cr.Category, &cr.Placement, &cr.CreatedAt, &cr.UpdatedAt)
+	cr, err := scanCreative(r.pool.QueryRow(ctx, `SELECT `+creativeColumns+` FROM creatives WHERE id = $1`, id))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &cr, nil
+	return cr, nil
 }
 
 // GetCampaign returns a campaign by id.
 func (r *AdRepository) GetCampaign(ctx context.Context, id int64) (*domain.Campaign, error) {
-	const query = `SELECT
-id, name, start_date, end_date, daily_budget, total_budget,
-remaining_daily_budget, remaining_total_budget, cpm_bid,
-cpc_bid, status, created_at, updated_at FROM campaigns WHERE id = $1`
-
-	var c domain.Campaign
-	err := r.pool.QueryRow(ctx, query, id).
-		Scan(&c.ID, &c.Name, &c.StartDate, &c.EndDate, &c.DailyBudget,
-			&c.TotalBudget, &c.RemainingDailyBudget, &c.RemainingTotalBudget,
-			&c.CPMBid, &c.CPCBid, &c.Status, &c.CreatedAt, &c.UpdatedAt)
+	c, err := scanCampaign(r.pool.QueryRow(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE id = $1`, id))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &c, nil
+	return c, nil
 }
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// BudgetRepository implements port.BudgetRepository using pgxpool.
+type BudgetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBudgetRepository returns a new repository instance.
+func NewBudgetRepository(pool *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{pool: pool}
+}
+
+// ResetDailyBudgets claims the day in budget_resets and restores daily
+// budgets in the same transaction. The primary key on reset_date makes
+// concurrent instances race for the claim; losers see no inserted row and
+// leave the budgets untouched. The very first claim only records the day:
+// a fresh deploy starts mid-day, and refilling then would let campaigns
+// spend their daily budget twice.
+func (r *BudgetRepository) ResetDailyBudgets(
+	ctx context.Context,
+	day time.Time,
+	timezone string,
+) (_ *domain.BudgetReset, err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	reset := domain.BudgetReset{Date: day, Timezone: timezone, ResetAt: time.Now().UTC()}
+
+	const claimQuery = `INSERT INTO budget_resets (reset_date, timezone, campaigns, reset_at)
+VALUES ($1, $2, 0, $3) ON CONFLICT (reset_date) DO NOTHING`
+
+	tag, err := tx.Exec(ctx, claimQuery, day, timezone, reset.ResetAt)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	var first bool
+	err = tx.QueryRow(ctx, `SELECT NOT EXISTS (SELECT 1 FROM budget_resets WHERE reset_date <> $1)`,
+		day).Scan(&first)
+	if err != nil || first {
+		return &reset, err
+	}
+
+	tag, err = tx.Exec(ctx, `UPDATE campaigns SET remaining_daily_budget = daily_budget, updated_at = $1
+WHERE status NOT IN ('archived', 'ended')`, reset.ResetAt)
+	if err != nil {
+		return nil, err
+	}
+	reset.Campaigns = tag.RowsAffected()
+
+	_, err = tx.Exec(ctx, `UPDATE budget_resets SET campaigns = $2 WHERE reset_date = $1`, day, reset.Campaigns)
+	if err != nil {
+		return nil, err
+	}
+	return &reset, nil
+}
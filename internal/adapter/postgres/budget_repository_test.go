@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestResetDailyBudgets ensures the first reset only claims its day and
+// later resets refill every campaign that can still serve.
+func TestResetDailyBudgets(t *testing.T) {
+	ctx := context.Background()
+	pool := testPool(t)
+	repo := NewBudgetRepository(pool)
+
+	if _, err := pool.Exec(ctx, `DELETE FROM budget_resets`); err != nil {
+		t.Fatalf("clear budget resets: %v", err)
+	}
+	now := time.Now().UTC()
+	ids := make(map[string]int64)
+	for _, status := range []string{"active", "paused", "archived", "ended"} {
+		var id int64
+		err := pool.QueryRow(ctx, `INSERT INTO campaigns
+    (name, start_date, end_date, daily_budget, total_budget, remaining_daily_budget,
+     remaining_total_budget, cpm_bid, cpc_bid, status, created_at, updated_at)
+VALUES ('reset', $1, $2, 1000, 5000, 100, 5000, 100000, 50, $3, $1, $1) RETURNING id`,
+			now, now.AddDate(0, 0, 7), status).Scan(&id)
+		if err != nil {
+			t.Fatalf("insert campaign: %v", err)
+		}
+		ids[status] = id
+	}
+	remaining := func(status string) int64 {
+		t.Helper()
+		var v int64
+		err := pool.QueryRow(ctx, `SELECT remaining_daily_budget FROM campaigns WHERE id = $1`, ids[status]).Scan(&v)
+		if err != nil {
+			t.Fatalf("select campaign: %v", err)
+		}
+		return v
+	}
+
+	day := now.Truncate(24 * time.Hour)
+	reset, err := repo.ResetDailyBudgets(ctx, day, "UTC")
+	if err != nil || reset == nil || reset.Campaigns != 0 {
+		t.Fatalf("first reset = %+v, %v", reset, err)
+	}
+	if got := remaining("active"); got != 100 {
+		t.Fatalf("first reset refilled the budget mid-day: %d", got)
+	}
+	if reset, err = repo.ResetDailyBudgets(ctx, day, "UTC"); err != nil || reset != nil {
+		t.Fatalf("repeated reset = %+v, %v", reset, err)
+	}
+
+	if reset, err = repo.ResetDailyBudgets(ctx, day.AddDate(0, 0, 1), "UTC"); err != nil || reset == nil {
+		t.Fatalf("next day reset = %+v, %v", reset, err)
+	}
+	for status, want := range map[string]int64{"active": 1000, "paused": 1000, "archived": 100, "ended": 100} {
+		if got := remaining(status); got != want {
+			t.Fatalf("%s campaign: remaining daily budget = %d, want %d", status, got, want)
+		}
+	}
+}
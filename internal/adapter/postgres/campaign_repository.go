@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// campaignColumns lists the campaigns columns in the order expected by
+// scanCampaign.
+const campaignColumns = `id, name, start_date, end_date, daily_budget, total_budget,
+remaining_daily_budget, remaining_total_budget, cpm_bid,
+cpc_bid, status, pacing_mode, frequency_caps, created_at, updated_at`
+
+// scanCampaign scans a row selected with campaignColumns.
+func scanCampaign(row pgx.Row) (*domain.Campaign, error) {
+	var c domain.Campaign
+	err := row.Scan(&c.ID, &c.Name, &c.StartDate, &c.EndDate, &c.DailyBudget,
+		&c.TotalBudget, &c.RemainingDailyBudget, &c.RemainingTotalBudget,
+		&c.CPMBid, &c.CPCBid, &c.Status, &c.PacingMode, &c.FrequencyCaps, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// frequencyCaps returns caps as a non-nil slice so they are stored as a
+// JSON array rather than NULL.
+func frequencyCaps(caps []domain.FrequencyCap) []domain.FrequencyCap {
+	if caps == nil {
+		return []domain.FrequencyCap{}
+	}
+	return caps
+}
+
+// CampaignRepository implements port.CampaignRepository using pgxpool.
+type CampaignRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCampaignRepository returns a new repository instance.
+func NewCampaignRepository(pool *pgxpool.Pool) *CampaignRepository {
+	return &CampaignRepository{pool: pool}
+}
+
+// CreateCampaign inserts a campaign with an empty, match-all targeting and
+// returns the stored row.
+func (r *CampaignRepository) CreateCampaign(ctx context.Context, c domain.Campaign) (*domain.Campaign, error) {
+	const query = `WITH c AS (
+    INSERT INTO campaigns
+        (name, start_date, end_date, daily_budget, total_budget, remaining_daily_budget,
+         remaining_total_budget, cpm_bid, cpc_bid, status, pacing_mode, frequency_caps, created_at, updated_at)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$13) RETURNING ` + campaignColumns + `
+), t AS (
+    INSERT INTO campaign_targeting (campaign_id, data) SELECT id, '{}' FROM c
+)
+SELECT ` + campaignColumns + ` FROM c`
+
+	now := time.Now().UTC()
+	return scanCampaign(r.pool.QueryRow(ctx, query,
+		c.Name, c.StartDate.UTC(), c.EndDate.UTC(), c.DailyBudget, c.TotalBudget,
+		c.RemainingDailyBudget, c.RemainingTotalBudget, c.CPMBid, c.CPCBid, c.Status, c.PacingMode,
+		frequencyCaps(c.FrequencyCaps), now))
+}
+
+// GetCampaign returns a campaign by id.
+func (r *CampaignRepository) GetCampaign(ctx context.Context, id int64) (*domain.Campaign, error) {
+	c, err := scanCampaign(r.pool.QueryRow(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return c, err
+}
+
+// ListCampaigns returns a page of campaigns ordered by id.
+func (r *CampaignRepository) ListCampaigns(ctx context.Context, req port.ListCampaignsReq) ([]domain.Campaign, error) {
+	const query = `SELECT ` + campaignColumns + ` FROM campaigns
+WHERE ($1 = '' OR status = $1) ORDER BY id LIMIT $2 OFFSET $3`
+
+	page := req.Page.Normalize()
+	rows, err := r.pool.Query(ctx, query, req.Status, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	campaigns := make([]domain.Campaign, 0)
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, *c)
+	}
+	return campaigns, rows.Err()
+}
+
+// UpdateCampaign performs a locked read-modify-write of a campaign.
+func (r *CampaignRepository) UpdateCampaign(
+	ctx context.Context,
+	id int64,
+	actor string,
+	update func(c *domain.Campaign) error,
+) (_ *domain.Campaign, err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	c, err := scanCampaign(tx.QueryRow(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE id = $1 FOR UPDATE`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	from := c.Status
+	if err = update(c); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if c.Status != from {
+		const transitionQuery = `INSERT INTO campaign_status_transitions
+(campaign_id, from_status, to_status, actor, created_at) VALUES ($1,$2,$3,$4,$5)`
+
+		if _, err = tx.Exec(ctx, transitionQuery, id, from, c.Status, actor, now); err != nil {
+			return nil, err
+		}
+	}
+
+	const updateQuery = `UPDATE campaigns SET
+name = $2, start_date = $3, end_date = $4, daily_budget = $5, total_budget = $6,
+remaining_daily_budget = $7, remaining_total_budget = $8, cpm_bid = $9, cpc_bid = $10,
+status = $11, pacing_mode = $12, frequency_caps = $13, updated_at = $14
+WHERE id = $1 RETURNING ` + campaignColumns
+
+	return scanCampaign(tx.QueryRow(ctx, updateQuery, id,
+		c.Name, c.StartDate.UTC(), c.EndDate.UTC(), c.DailyBudget, c.TotalBudget,
+		c.RemainingDailyBudget, c.RemainingTotalBudget, c.CPMBid, c.CPCBid, c.Status,
+		c.PacingMode, frequencyCaps(c.FrequencyCaps), now))
+}
+
+// ListStatusTransitions returns the status history of a campaign.
+func (r *CampaignRepository) ListStatusTransitions(
+	ctx context.Context,
+	campaignID int64,
+) ([]domain.StatusTransition, error) {
+	const query = `SELECT id, campaign_id, from_status, to_status, actor, created_at
+FROM campaign_status_transitions WHERE campaign_id = $1 ORDER BY id`
+
+	rows, err := r.pool.Query(ctx, query, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transitions := make([]domain.StatusTransition, 0)
+	for rows.Next() {
+		var t domain.StatusTransition
+		if err = rows.Scan(&t.ID, &t.CampaignID, &t.From, &t.To, &t.Actor, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
+
+// ListAutoTransitionCandidates returns campaigns the lifecycle worker may
+// need to move.
+func (r *CampaignRepository) ListAutoTransitionCandidates(
+	ctx context.Context,
+	now time.Time,
+) ([]domain.Campaign, error) {
+	const query = `SELECT ` + campaignColumns + ` FROM campaigns
+WHERE (status IN ('active', 'paused', 'budget_exhausted') AND end_date <= $1)
+   OR (status = 'active' AND remaining_total_budget <= 0)
+ORDER BY id`
+
+	rows, err := r.pool.Query(ctx, query, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	campaigns := make([]domain.Campaign, 0)
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, *c)
+	}
+	return campaigns, rows.Err()
+}
+
+// GetTargeting returns the stored targeting of a campaign.
+func (r *CampaignRepository) GetTargeting(ctx context.Context, campaignID int64) (*domain.Targeting, error) {
+	var t domain.Targeting
+	err := r.pool.QueryRow(ctx, `SELECT data FROM campaign_targeting WHERE campaign_id = $1`, campaignID).Scan(&t)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SetTargeting upserts the targeting of a campaign.
+func (r *CampaignRepository) SetTargeting(ctx context.Context, campaignID int64, t domain.Targeting) error {
+	const query = `INSERT INTO campaign_targeting (campaign_id, data) VALUES ($1, $2)
+ON CONFLICT (campaign_id) DO UPDATE SET data = EXCLUDED.data`
+
+	_, err := r.pool.Exec(ctx, query, campaignID, t)
+	return err
+}
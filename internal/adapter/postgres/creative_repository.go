@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// creativeColumns lists the creatives columns in the order expected by
+// scanCreative.
+const creativeColumns = `id, campaign_id, title, video_url, landing_url,
+duration, language, category, placement, frequency_caps, created_at, updated_at`
+
+// scanCreative scans a row selected with creativeColumns. Nullable text
+// columns are read as empty strings.
+func scanCreative(row pgx.Row) (*domain.Creative, error) {
+	var (
+		cr                            domain.Creative
+		language, category, placement *string
+	)
+	err := row.Scan(&cr.ID, &cr.CampaignID, &cr.Title, &cr.VideoURL, &cr.LandingURL,
+		&cr.Duration, &language, &category, &placement, &cr.FrequencyCaps, &cr.CreatedAt, &cr.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	cr.Language = deref(language)
+	cr.Category = deref(category)
+	cr.Placement = deref(placement)
+	return &cr, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// CreativeRepository implements port.CreativeRepository using pgxpool.
+type CreativeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCreativeRepository returns a new repository instance.
+func NewCreativeRepository(pool *pgxpool.Pool) *CreativeRepository {
+	return &CreativeRepository{pool: pool}
+}
+
+// CreateCreative inserts a creative and returns the stored row.
+func (r *CreativeRepository) CreateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error) {
+	const query = `INSERT INTO creatives
+(campaign_id, title, video_url, landing_url, duration, language, category, placement,
+ frequency_caps, created_at, updated_at)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$10) RETURNING ` + creativeColumns
+
+	return scanCreative(r.pool.QueryRow(ctx, query,
+		c.CampaignID, c.Title, c.VideoURL, c.LandingURL, c.Duration,
+		c.Language, c.Category, c.Placement, frequencyCaps(c.FrequencyCaps), time.Now().UTC()))
+}
+
+// GetCreative returns a creative by id.
+func (r *CreativeRepository) GetCreative(ctx context.Context, id int64) (*domain.Creative, error) {
+	cr, err := scanCreative(r.pool.QueryRow(ctx, `SELECT `+creativeColumns+` FROM creatives WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return cr, err
+}
+
+// ListCreatives returns a page of the campaign's creatives and their total
+// count.
+func (r *CreativeRepository) ListCreatives(
+	ctx context.Context,
+	campaignID int64,
+	page port.Page,
+) (*port.CreativeList, error) {
+	page = page.Normalize()
+	list := &port.CreativeList{
+		Items:  make([]domain.Creative, 0),
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM creatives WHERE campaign_id = $1`, campaignID).
+		Scan(&list.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `SELECT ` + creativeColumns + ` FROM creatives
+WHERE campaign_id = $1 ORDER BY id LIMIT $2 OFFSET $3`
+
+	rows, err := r.pool.Query(ctx, query, campaignID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		cr, err := scanCreative(rows)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, *cr)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// UpdateCreative replaces the editable fields of a creative.
+func (r *CreativeRepository) UpdateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error) {
+	const query = `UPDATE creatives SET
+title = $3, video_url = $4, landing_url = $5, duration = $6,
+language = $7, category = $8, placement = $9, frequency_caps = $10, updated_at = $11
+WHERE id = $1 AND campaign_id = $2 RETURNING ` + creativeColumns
+
+	cr, err := scanCreative(r.pool.QueryRow(ctx, query,
+		c.ID, c.CampaignID, c.Title, c.VideoURL, c.LandingURL, c.Duration,
+		c.Language, c.Category, c.Placement, frequencyCaps(c.FrequencyCaps), time.Now().UTC()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return cr, err
+}
+
+// DeleteCreative removes a creative unless impressions reference it.
+func (r *CreativeRepository) DeleteCreative(ctx context.Context, campaignID, id int64) (bool, error) {
+	const query = `DELETE FROM creatives cr
+WHERE cr.id = $1 AND cr.campaign_id = $2
+  AND NOT EXISTS (SELECT 1 FROM impressions i WHERE i.creative_id = cr.id)`
+
+	tag, err := r.pool.Exec(ctx, query, id, campaignID)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() > 0 {
+		return true, nil
+	}
+
+	// nothing deleted: either the creative does not exist or it was served
+	var exists bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM creatives WHERE id = $1 AND campaign_id = $2)`,
+		id, campaignID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, port.ErrConflict
+	}
+	return false, nil
+}
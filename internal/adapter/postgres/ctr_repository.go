@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// CTRRepository implements port.CTRRepository on top of the impressions
+// and clicks tables.
+type CTRRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCTRRepository returns a new repository instance.
+func NewCTRRepository(pool *pgxpool.Pool) *CTRRepository {
+	return &CTRRepository{pool: pool}
+}
+
+// ListEventCounts returns impression and click counts per creative for
+// events made after since.
+func (r *CTRRepository) ListEventCounts(ctx context.Context, since time.Time) ([]domain.EventCounts, error) {
+	const query = `SELECT i.campaign_id, i.creative_id, i.impressions, COALESCE(c.clicks, 0)
+FROM (SELECT campaign_id, creative_id, count(*) AS impressions
+        FROM impressions WHERE created_at > $1
+       GROUP BY campaign_id, creative_id) i
+LEFT JOIN (SELECT creative_id, count(*) AS clicks
+             FROM clicks WHERE created_at > $1
+            GROUP BY creative_id) c ON c.creative_id = i.creative_id`
+
+	rows, err := r.pool.Query(ctx, query, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.EventCounts
+	for rows.Next() {
+		var c domain.EventCounts
+		if err = rows.Scan(&c.CampaignID, &c.CreativeID, &c.Impressions, &c.Clicks); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/port"
+)
+
+// ExportRepository implements port.ExportRepository using pgxpool. Events
+// are read through a server-side cursor batchSize rows at a time, so memory
+// use does not depend on the size of the export.
+type ExportRepository struct {
+	pool      *pgxpool.Pool
+	batchSize int
+}
+
+// NewExportRepository returns a new repository instance fetching
+// batchSize rows per round trip.
+func NewExportRepository(pool *pgxpool.Pool, batchSize int) *ExportRepository {
+	return &ExportRepository{pool: pool, batchSize: max(batchSize, 1)}
+}
+
+// StreamEvents declares a cursor over the impressions and clicks of the
+// period in a read-only repeatable read transaction, so the export sees a
+// single snapshot, and fetches it batch by batch.
+func (r *ExportRepository) StreamEvents(ctx context.Context, q port.ExportQuery, fn func(port.ExportedEvent) error) error {
+	args := []interface{}{q.From.UTC(), q.To.UTC()}
+	where := "WHERE created_at >= $1 AND created_at < $2"
+	if q.CampaignID != nil {
+		args = append(args, *q.CampaignID)
+		where += fmt.Sprintf(" AND campaign_id = $%d", len(args))
+	}
+	after := ""
+	if q.After != nil {
+		args = append(args, q.After.CreatedAt.UTC(), string(q.After.Kind), q.After.ID)
+		after = fmt.Sprintf("WHERE (created_at, kind, id) > ($%d, $%d, $%d)", len(args)-2, len(args)-1, len(args))
+	}
+	query := `DECLARE export_events NO SCROLL CURSOR FOR
+SELECT kind, id, token, campaign_id, creative_id, user_id, cost, created_at FROM (
+    SELECT 'impression' AS kind, id, token, campaign_id, creative_id, user_id, cost, created_at
+    FROM impressions ` + where + `
+    UNION ALL
+    SELECT 'click', id, token, campaign_id, creative_id, user_id, cost, created_at
+    FROM clicks ` + where + `
+) e ` + after + `
+ORDER BY created_at, kind, id`
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	// Nothing is written; rolling back closes the cursor.
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+	var e port.ExportedEvent
+	scans := []any{&e.Kind, &e.ID, &e.Token, &e.CampaignID, &e.CreativeID, &e.UserID, &e.Cost, &e.CreatedAt}
+	fetch := fmt.Sprintf("FETCH %d FROM export_events", r.batchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return err
+		}
+		tag, err := pgx.ForEachRow(rows, scans, func() error { return fn(e) })
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() < int64(r.batchSize) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// FrequencyStore implements port.FrequencyStore on top of the impressions
+// and impression_reservations tables, which makes it consistent across
+// instances.
+type FrequencyStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewFrequencyStore returns a new store instance.
+func NewFrequencyStore(pool *pgxpool.Pool) *FrequencyStore {
+	return &FrequencyStore{pool: pool}
+}
+
+// Events returns the user's impressions and unexpired reservations of the
+// given campaigns made after since in a single query. A reservation moves
+// to impressions when it is committed, so nothing is counted twice.
+func (s *FrequencyStore) Events(
+	ctx context.Context,
+	userID string,
+	campaignIDs []int64,
+	since time.Time,
+) ([]domain.FrequencyEvent, error) {
+	const query = `SELECT campaign_id, creative_id, created_at FROM impressions
+WHERE user_id = $1 AND created_at > $2 AND campaign_id = ANY($3)
+UNION ALL
+SELECT campaign_id, creative_id, created_at FROM impression_reservations
+WHERE user_id = $1 AND created_at > $2 AND campaign_id = ANY($3) AND expires_at > $4`
+
+	rows, err := s.pool.Query(ctx, query, userID, since.UTC(), campaignIDs, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.FrequencyEvent
+	for rows.Next() {
+		var e domain.FrequencyEvent
+		if err = rows.Scan(&e.CampaignID, &e.CreativeID, &e.At); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// Record is a no-op: reservations are already stored by the AdRepository.
+func (s *FrequencyStore) Record(context.Context, string, domain.FrequencyEvent) error {
+	return nil
+}
@@ -0,0 +1,22 @@
+package postgres
+
+import "testing"
+
+// TestCheckSchema checks which schema versions keep an instance ready.
+func TestCheckSchema(t *testing.T) {
+	for _, tc := range []struct {
+		version uint
+		dirty   bool
+		ok      bool
+	}{
+		{version: 15, ok: true},
+		{version: 16, ok: true},
+		{version: 14, ok: false},
+		{version: 15, dirty: true, ok: false},
+		{version: 16, dirty: true, ok: false},
+	} {
+		if err := checkSchema(tc.version, tc.dirty, 15); (err == nil) != tc.ok {
+			t.Errorf("version %d dirty %v: err = %v", tc.version, tc.dirty, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// candidatesChannel is notified by the triggers of migration 005 whenever
+// campaigns, creatives or targeting change in a way that affects ad
+// selection.
+const candidatesChannel = "ad_candidates_changed"
+
+// listenRetryDelay is how long ListenCandidateChanges waits before
+// reconnecting after the listening connection fails.
+const listenRetryDelay = 5 * time.Second
+
+// ListenCandidateChanges subscribes to candidate change notifications on a
+// dedicated connection taken from the pool. The returned channel receives a
+// value after each notification and after every (re)connect, since
+// notifications sent while disconnected are lost. Bursts are coalesced. The
+// channel is closed once ctx is cancelled.
+func ListenCandidateChanges(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+	signal := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(changes)
+		for {
+			err := listen(ctx, pool, signal)
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("candidate change listener failed", slog.Any("error", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(listenRetryDelay):
+			}
+		}
+	}()
+	return changes
+}
+
+// listen holds a connection in LISTEN mode and calls signal for every
+// notification until ctx is cancelled or the connection fails.
+func listen(ctx context.Context, pool *pgxpool.Pool, signal func()) error {
+	pooled, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// a listening session must not return to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+candidatesChannel); err != nil {
+		return err
+	}
+	signal()
+	for {
+		if _, err = conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		signal()
+	}
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/adapter/hll"
+)
+
+// RollupRepository implements port.RollupRepository using pgxpool. The
+// covered range lives in the single row of stats_rollup_state, which is
+// locked while hours are aggregated so concurrent instances roll up each
+// hour exactly once.
+type RollupRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRollupRepository returns a new repository instance.
+func NewRollupRepository(pool *pgxpool.Pool) *RollupRepository {
+	return &RollupRepository{pool: pool}
+}
+
+// RollupRange returns the hours covered by the rollups.
+func (r *RollupRepository) RollupRange(ctx context.Context) (from, until time.Time, err error) {
+	err = r.pool.QueryRow(ctx, `SELECT rolled_from, rolled_until FROM stats_rollup_state`).Scan(&from, &until)
+	return from, until, err
+}
+
+// FirstEventAt returns the time of the oldest impression or click.
+func (r *RollupRepository) FirstEventAt(ctx context.Context) (time.Time, error) {
+	const query = `SELECT LEAST((SELECT min(created_at) FROM impressions), (SELECT min(created_at) FROM clicks))`
+
+	var first *time.Time
+	if err := r.pool.QueryRow(ctx, query).Scan(&first); err != nil || first == nil {
+		return time.Time{}, err
+	}
+	return *first, nil
+}
+
+// RollUp aggregates the hours between rolled_until and until.
+func (r *RollupRepository) RollUp(ctx context.Context, until time.Time) (hours int64, err error) {
+	until = until.UTC().Truncate(time.Hour)
+	err = r.withState(ctx, func(tx pgx.Tx, _, rolledUntil time.Time) error {
+		if !until.After(rolledUntil) {
+			return nil
+		}
+		if err := rollUpHours(ctx, tx, rolledUntil, until); err != nil {
+			return err
+		}
+		hours = int64(until.Sub(rolledUntil) / time.Hour)
+		_, err := tx.Exec(ctx, `UPDATE stats_rollup_state SET rolled_until = $1`, until)
+		return err
+	})
+	return hours, err
+}
+
+// Backfill aggregates the hours between from and rolled_from.
+func (r *RollupRepository) Backfill(ctx context.Context, from time.Time) (hours int64, err error) {
+	from = from.UTC().Truncate(time.Hour)
+	err = r.withState(ctx, func(tx pgx.Tx, rolledFrom, _ time.Time) error {
+		if !from.Before(rolledFrom) {
+			return nil
+		}
+		if err := rollUpHours(ctx, tx, from, rolledFrom); err != nil {
+			return err
+		}
+		hours = int64(rolledFrom.Sub(from) / time.Hour)
+		_, err := tx.Exec(ctx, `UPDATE stats_rollup_state SET rolled_from = $1`, from)
+		return err
+	})
+	return hours, err
+}
+
+// withState runs fn in a transaction holding the lock on the rollup state
+// and passes it the covered range.
+func (r *RollupRepository) withState(
+	ctx context.Context,
+	fn func(tx pgx.Tx, rolledFrom, rolledUntil time.Time) error,
+) (err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var rolledFrom, rolledUntil time.Time
+	err = tx.QueryRow(ctx, `SELECT rolled_from, rolled_until FROM stats_rollup_state FOR UPDATE`).
+		Scan(&rolledFrom, &rolledUntil)
+	if err != nil {
+		return err
+	}
+	return fn(tx, rolledFrom, rolledUntil)
+}
+
+// rollUpHours replaces the rollups of the hours [from, to) with aggregates
+// of the raw events.
+func rollUpHours(ctx context.Context, tx pgx.Tx, from, to time.Time) error {
+	const statsQuery = `INSERT INTO stats_hourly (hour, campaign_id, creative_id, impressions, clicks, cost)
+SELECT hour, campaign_id, creative_id, sum(impressions), sum(clicks), sum(cost) FROM (
+    SELECT date_trunc('hour', created_at) AS hour, campaign_id, creative_id,
+    count(*) AS impressions, 0 AS clicks, sum(cost) AS cost
+    FROM impressions WHERE created_at >= $1 AND created_at < $2 GROUP BY 1, 2, 3
+    UNION ALL
+    SELECT date_trunc('hour', created_at), campaign_id, creative_id, 0, count(*), sum(cost)
+    FROM clicks WHERE created_at >= $1 AND created_at < $2 GROUP BY 1, 2, 3
+) e GROUP BY 1, 2, 3`
+
+	const eventsQuery = `INSERT INTO stats_hourly_events (hour, campaign_id, creative_id, event, events)
+SELECT date_trunc('hour', created_at), campaign_id, creative_id, event, count(*)
+FROM tracking_events WHERE created_at >= $1 AND created_at < $2 GROUP BY 1, 2, 3, 4`
+
+	for _, query := range []string{
+		`DELETE FROM stats_hourly WHERE hour >= $1 AND hour < $2`,
+		`DELETE FROM stats_hourly_events WHERE hour >= $1 AND hour < $2`,
+		`DELETE FROM stats_hourly_reach WHERE hour >= $1 AND hour < $2`,
+		statsQuery,
+		eventsQuery,
+	} {
+		if _, err := tx.Exec(ctx, query, from, to); err != nil {
+			return err
+		}
+	}
+	return rollUpReach(ctx, tx, from, to)
+}
+
+// rollUpReach stores a sketch of the users reached per campaign and hour
+// of [from, to).
+func rollUpReach(ctx context.Context, tx pgx.Tx, from, to time.Time) error {
+	sketches, err := hourlyReach(ctx, tx, from, to)
+	if err != nil {
+		return err
+	}
+	rows := make([][]interface{}, 0, len(sketches))
+	for k, sketch := range sketches {
+		data, err := sketch.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []interface{}{k.hour, k.campaignID, data})
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"stats_hourly_reach"},
+		[]string{"hour", "campaign_id", "sketch"}, pgx.CopyFromRows(rows))
+	return err
+}
+
+type reachKey struct {
+	hour       time.Time
+	campaignID int64
+}
+
+// userRegister selects the HyperLogLog register and rank of the user of
+// each impression, hashed like hll.Sketch.Add, so that a sketch is read as
+// at most one row per register instead of one row per user.
+var userRegister = fmt.Sprintf(`substring(h FROM 1 FOR %d)::int AS register,
+    position(B'1' IN substring(h FROM %d) || B'1') AS rank
+    FROM impressions CROSS JOIN LATERAL (SELECT ('x' || left(md5(user_id), 16))::bit(64) AS h) u`,
+	hll.Precision, hll.Precision+1)
+
+// hourlyReach sketches the distinct users of the impressions in [from, to)
+// per campaign and hour.
+func hourlyReach(ctx context.Context, tx pgx.Tx, from, to time.Time) (map[reachKey]*hll.Sketch, error) {
+	query := `SELECT hour, campaign_id, register, max(rank) FROM (
+    SELECT date_trunc('hour', created_at) AS hour, campaign_id, ` + userRegister + `
+    WHERE created_at >= $1 AND created_at < $2
+) r GROUP BY 1, 2, 3`
+
+	rows, err := tx.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sketches := make(map[reachKey]*hll.Sketch)
+	for rows.Next() {
+		var (
+			k              reachKey
+			register, rank int32
+		)
+		if err = rows.Scan(&k.hour, &k.campaignID, &register, &rank); err != nil {
+			return nil, err
+		}
+		if sketches[k] == nil {
+			sketches[k] = hll.New()
+		}
+		sketches[k].AddRegister(int(register), uint8(rank))
+	}
+	return sketches, rows.Err()
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/db"
+)
+
+// testPool connects to the scratch database at TEST_PSQL_ADDR and applies
+// the migrations. Tests that need a database are skipped without it.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	addr := os.Getenv("TEST_PSQL_ADDR")
+	if addr == "" {
+		t.Skip("TEST_PSQL_ADDR is not set")
+	}
+	if err := db.Migrate(addr); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	pool, err := pgxpool.New(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// statsFixture holds the ids of the events inserted by seedStats.
+type statsFixture struct {
+	campaignID int64
+	creativeA  int64
+	creativeB  int64
+}
+
+// seedStats inserts a campaign with impressions, clicks and tracking events
+// around the period [day+09:20, day+12:30].
+func seedStats(ctx context.Context, t *testing.T, pool *pgxpool.Pool, day time.Time) statsFixture {
+	t.Helper()
+	var f statsFixture
+	err := pool.QueryRow(ctx, `INSERT INTO campaigns
+    (name, start_date, end_date, daily_budget, total_budget, remaining_daily_budget,
+     remaining_total_budget, cpm_bid, cpc_bid, status, created_at, updated_at)
+VALUES ('stats', $1, $2, 0, 0, 0, 0, 100000, 50, 'ended', $1, $1) RETURNING id`,
+		day, day.Add(24*time.Hour)).Scan(&f.campaignID)
+	if err != nil {
+		t.Fatalf("insert campaign: %v", err)
+	}
+	for _, id := range []*int64{&f.creativeA, &f.creativeB} {
+		err = pool.QueryRow(ctx, `INSERT INTO creatives
+    (campaign_id, title, video_url, landing_url, duration, created_at, updated_at)
+VALUES ($1, 'stats', 'https://example.com/v.mp4', 'https://example.com', 30, $2, $2) RETURNING id`,
+			f.campaignID, day).Scan(id)
+		if err != nil {
+			t.Fatalf("insert creative: %v", err)
+		}
+	}
+
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	impressions := []struct {
+		creativeID int64
+		user       string
+		at         time.Time
+	}{
+		{f.creativeA, "u1", at(9, 10)}, // before the period
+		{f.creativeA, "u1", at(9, 30)},
+		{f.creativeA, "u2", at(10, 5)},
+		{f.creativeB, "u1", at(10, 40)},
+		{f.creativeA, "u3", at(11, 15)},
+		{f.creativeA, "u2", at(12, 10)},
+		{f.creativeA, "u4", at(12, 45)}, // after the period
+	}
+	ids := make([]int64, len(impressions))
+	for i, imp := range impressions {
+		err = pool.QueryRow(ctx, `INSERT INTO impressions
+    (token, creative_id, campaign_id, user_id, cost, created_at)
+VALUES (gen_random_uuid()::text, $1, $2, $3, 100, $4) RETURNING id`,
+			imp.creativeID, f.campaignID, imp.user, imp.at).Scan(&ids[i])
+		if err != nil {
+			t.Fatalf("insert impression: %v", err)
+		}
+	}
+	for _, c := range []struct {
+		imp int
+		at  time.Time
+	}{{2, at(10, 7)}, {5, at(12, 15)}, {6, at(12, 50)}} {
+		_, err = pool.Exec(ctx, `INSERT INTO clicks
+    (token, impression_id, creative_id, campaign_id, user_id, cost, created_at)
+SELECT gen_random_uuid()::text, id, creative_id, campaign_id, user_id, 50, $2 FROM impressions WHERE id = $1`,
+			ids[c.imp], c.at)
+		if err != nil {
+			t.Fatalf("insert click: %v", err)
+		}
+	}
+	for _, e := range []struct {
+		imp   int
+		event domain.TrackingEvent
+		at    time.Time
+	}{
+		{1, domain.EventStart, at(9, 31)},
+		{2, domain.EventStart, at(10, 6)},
+		{2, domain.EventComplete, at(10, 20)},
+		{4, domain.EventStart, at(11, 16)},
+	} {
+		_, err = pool.Exec(ctx, `INSERT INTO tracking_events
+    (token, impression_id, creative_id, campaign_id, event, created_at)
+SELECT token, id, creative_id, campaign_id, $2, $3 FROM impressions WHERE id = $1`,
+			ids[e.imp], e.event, e.at)
+		if err != nil {
+			t.Fatalf("insert tracking event: %v", err)
+		}
+	}
+	return f
+}
+
+// TestStatsRollups reads the stats of a period from the raw tables, rolls
+// its hours up and checks that the rollups and the raw edges add up to the
+// same answers.
+func TestStatsRollups(t *testing.T) {
+	ctx := context.Background()
+	pool := testPool(t)
+	rollups := NewRollupRepository(pool)
+	repo := NewAdRepository(pool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Events older than the rollups are only read from the raw tables
+	// until the backfill reaches them.
+	rolledFrom, _, err := rollups.RollupRange(ctx)
+	if err != nil {
+		t.Fatalf("RollupRange error: %v", err)
+	}
+	day := rolledFrom.UTC().Truncate(24*time.Hour).AddDate(0, 0, -2)
+	f := seedStats(ctx, t, pool, day)
+
+	req := port.StatsReq{From: day.Add(9*time.Hour + 20*time.Minute), To: day.Add(12*time.Hour + 30*time.Minute), CampaignID: &f.campaignID}
+	check := func(stage string) {
+		t.Helper()
+		lo, hi, err := repo.rollupSplit(ctx, req.From, req.To)
+		if err != nil {
+			t.Fatalf("%s: rollupSplit error: %v", stage, err)
+		}
+		if stage == "rolled up" && (!lo.Equal(day.Add(10*time.Hour)) || !hi.Equal(day.Add(12*time.Hour))) {
+			t.Fatalf("%s: rolled up hours = [%v, %v)", stage, lo, hi)
+		}
+
+		stats, err := repo.GetStats(ctx, req)
+		if err != nil {
+			t.Fatalf("%s: GetStats error: %v", stage, err)
+		}
+		if stats.Impressions != 5 || stats.Clicks != 2 || stats.Cost != 600 || stats.Reach != 3 {
+			t.Fatalf("%s: stats = %+v", stage, stats)
+		}
+		if stats.Events[domain.EventStart] != 3 || stats.Events[domain.EventComplete] != 1 {
+			t.Fatalf("%s: events = %v", stage, stats.Events)
+		}
+
+		buckets, err := repo.GetStatsTimeSeries(ctx, port.TimeSeriesReq{
+			StatsReq: req, Granularity: port.GranularityHour, Location: time.UTC,
+		})
+		if err != nil {
+			t.Fatalf("%s: GetStatsTimeSeries error: %v", stage, err)
+		}
+		want := []port.StatsBucket{
+			{Start: day.Add(9 * time.Hour), Impressions: 1, Clicks: 0, Cost: 100},
+			{Start: day.Add(10 * time.Hour), Impressions: 2, Clicks: 1, Cost: 250},
+			{Start: day.Add(11 * time.Hour), Impressions: 1, Clicks: 0, Cost: 100},
+			{Start: day.Add(12 * time.Hour), Impressions: 1, Clicks: 1, Cost: 150},
+		}
+		if len(buckets) != len(want) {
+			t.Fatalf("%s: buckets = %+v", stage, buckets)
+		}
+		for i := range want {
+			b := buckets[i]
+			if !b.Start.Equal(want[i].Start) || b.Impressions != want[i].Impressions ||
+				b.Clicks != want[i].Clicks || b.Cost != want[i].Cost {
+				t.Fatalf("%s: bucket %d = %+v, want %+v", stage, i, b, want[i])
+			}
+		}
+
+		rows, err := repo.GetGroupedStats(ctx, port.GroupedStatsReq{StatsReq: req, GroupBy: port.DimensionCreative})
+		if err != nil {
+			t.Fatalf("%s: GetGroupedStats error: %v", stage, err)
+		}
+		if len(rows) != 2 ||
+			rows[0].Impressions != 4 || rows[0].Clicks != 2 || rows[0].Cost != 500 ||
+			rows[1].Impressions != 1 || rows[1].Clicks != 0 || rows[1].Cost != 100 {
+			t.Fatalf("%s: rows by creative = %+v", stage, rows)
+		}
+	}
+
+	check("raw")
+	if _, err = rollups.Backfill(ctx, day); err != nil {
+		t.Fatalf("Backfill error: %v", err)
+	}
+	check("rolled up")
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHourSpan checks the whole hours of a period.
+func TestHourSpan(t *testing.T) {
+	from := time.Date(2025, 6, 1, 10, 15, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC)
+
+	lo, hi := hourSpan(from, to)
+	if !lo.Equal(from.Add(45*time.Minute)) || !hi.Equal(to) {
+		t.Fatalf("span = [%v, %v)", lo, hi)
+	}
+	lo, _ = hourSpan(to, to)
+	if !lo.Equal(to) {
+		t.Fatalf("aligned start moved to %v", lo)
+	}
+}
+
+// TestWholeHourOffsets checks which zones can bucket hourly rollups.
+func TestWholeHourOffsets(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+
+	for loc, want := range map[*time.Location]bool{
+		time.UTC:                             true,
+		time.FixedZone("UTC+3", 3*60*60):     true,
+		time.FixedZone("UTC+5:30", 11*30*60): false,
+	} {
+		if got := wholeHourOffsets(loc, from, to); got != want {
+			t.Errorf("%s: got %v, want %v", loc, got, want)
+		}
+	}
+}
+
+// TestCoveredSpan checks which hours of a period are read from the rollups.
+func TestCoveredSpan(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	from, to := day.Add(9*time.Hour+20*time.Minute), day.Add(15*time.Hour+30*time.Minute)
+
+	for name, tc := range map[string]struct {
+		rolledFrom, rolledUntil time.Time
+		lo, hi                  time.Time
+	}{
+		"covered":      {day, day.Add(24 * time.Hour), day.Add(10 * time.Hour), day.Add(15 * time.Hour)},
+		"rolled later": {day.Add(12 * time.Hour), day.Add(24 * time.Hour), day.Add(12 * time.Hour), day.Add(15 * time.Hour)},
+		"rolled until": {day, day.Add(13 * time.Hour), day.Add(10 * time.Hour), day.Add(13 * time.Hour)},
+		"not rolled":   {day.Add(16 * time.Hour), day.Add(16 * time.Hour), day.Add(16 * time.Hour), day.Add(16 * time.Hour)},
+		"rolled past":  {day, day.Add(9 * time.Hour), day.Add(10 * time.Hour), day.Add(10 * time.Hour)},
+	} {
+		lo, hi := coveredSpan(from, to, tc.rolledFrom, tc.rolledUntil)
+		if !lo.Equal(tc.lo) || !hi.Equal(tc.hi) {
+			t.Errorf("%s: span = [%v, %v), want [%v, %v)", name, lo, hi, tc.lo, tc.hi)
+		}
+	}
+}
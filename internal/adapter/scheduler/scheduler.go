@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is a unit of periodic background work. Run is called once when the
+// scheduler starts and then every Interval until the scheduler context is
+// cancelled. Runs of the same job never overlap.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs in their own goroutines. It is an inbound
+// adapter: jobs drive use cases the same way HTTP handlers do. Jobs must be
+// safe to run concurrently on several instances, e.g. by being idempotent.
+type Scheduler struct {
+	logger *slog.Logger
+	jobs   []Job
+	wg     sync.WaitGroup
+}
+
+// New creates an empty scheduler.
+func New(logger *slog.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Add registers a job. Jobs must be added before Start.
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches all registered jobs. They stop when ctx is cancelled; use
+// Wait to block until the in-flight runs have returned.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+}
+
+// Wait blocks until every job goroutine has exited.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.logger.Info("job started", slog.String("job", job.Name), slog.Duration("interval", job.Interval))
+	for {
+		if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("job error", slog.String("job", job.Name), slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			s.logger.Info("job stopped", slog.String("job", job.Name))
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/adapter/usecase"
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// TestJobRunsInOrder ensures a job runs once on start and then on every
+// tick, one run after another, and that Wait returns after cancellation.
+func TestJobRunsInOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		runs     []int
+		inFlight atomic.Int32
+		overlap  atomic.Bool
+	)
+	done := make(chan struct{})
+	s := New(discardLogger())
+	s.Add(Job{
+		Name:     "ordered",
+		Interval: time.Millisecond,
+		Run: func(context.Context) error {
+			if inFlight.Add(1) > 1 {
+				overlap.Store(true)
+			}
+			defer inFlight.Add(-1)
+			// Outlast several ticks so an overlapping run would be caught.
+			time.Sleep(3 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			runs = append(runs, len(runs)+1)
+			if len(runs) == 5 {
+				close(done)
+			}
+			return nil
+		},
+	})
+	s.Start(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run 5 times")
+	}
+	cancel()
+	s.Wait()
+
+	if overlap.Load() {
+		t.Fatal("runs of the same job overlapped")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for i, n := range runs {
+		if n != i+1 {
+			t.Fatalf("runs = %v, want consecutive runs", runs)
+		}
+	}
+}
+
+// TestJobRunsOnStart ensures a job runs immediately instead of waiting for
+// its first interval.
+func TestJobRunsOnStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ran := make(chan struct{}, 1)
+	s := New(discardLogger())
+	s.Add(Job{
+		Name:     "hourly",
+		Interval: time.Hour,
+		Run: func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		},
+	})
+	s.Start(ctx)
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run on start")
+	}
+	cancel()
+	s.Wait()
+}
+
+// TestDailyResetIdempotent runs the daily budget reset job on two
+// schedulers, as on two instances, and ensures the day is reset once.
+func TestDailyResetIdempotent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	now := day.Add(9 * time.Hour)
+
+	// The mock mirrors the budget_resets primary key: the first claim of
+	// a day wins, later claims see the day as already reset.
+	var (
+		mu      sync.Mutex
+		claimed = map[time.Time]bool{}
+		calls   int
+	)
+	repo := mocks.NewMockBudgetRepository(t)
+	repo.EXPECT().ResetDailyBudgets(mock.Anything, day, "UTC").
+		RunAndReturn(func(_ context.Context, d time.Time, tz string) (*domain.BudgetReset, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if claimed[d] {
+				return nil, nil
+			}
+			claimed[d] = true
+			return &domain.BudgetReset{Date: d, Timezone: tz, Campaigns: 3}, nil
+		})
+	budgets := usecase.NewBudgetUseCase(repo, time.UTC)
+
+	var resets atomic.Int32
+	job := Job{
+		Name:     "daily-budget-reset",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			reset, err := budgets.ResetDailyBudgets(ctx, now)
+			if reset != nil {
+				resets.Add(1)
+			}
+			return err
+		},
+	}
+
+	instances := []*Scheduler{New(discardLogger()), New(discardLogger())}
+	for _, s := range instances {
+		s.Add(job)
+		s.Start(ctx)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := calls
+		mu.Unlock()
+		if n >= 10 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("reset job ran %d times, want at least 10", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	for _, s := range instances {
+		s.Wait()
+	}
+
+	if got := resets.Load(); got != 1 {
+		t.Fatalf("day reset %d times, want 1", got)
+	}
+}
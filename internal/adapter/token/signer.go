@@ -0,0 +1,182 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// minSecretLen is the shortest secret accepted for HMAC-SHA256 keys.
+const minSecretLen = 16
+
+// clockSkew is how far in the future an issue time may lie, to tolerate
+// clock differences between instances.
+const clockSkew = time.Minute
+
+// Key is a named HMAC secret. The ID is embedded in tokens so the verifier
+// knows which key signed them.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// ParseKeys parses a comma separated list of "id:secret" keys such as
+// "2025-06:s3cr3t...,2025-01:0ld...". The first key is the current one.
+func ParseKeys(s string) ([]Key, error) {
+	var keys []Key
+	seen := make(map[string]struct{})
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(part, ":")
+		if !ok || !validKeyID(id) {
+			return nil, fmt.Errorf("%w: token key must look like id:secret with an alphanumeric id", domain.ErrValidation)
+		}
+		if len(secret) < minSecretLen {
+			return nil, fmt.Errorf("%w: token key %q is shorter than %d bytes", domain.ErrValidation, id, minSecretLen)
+		}
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("%w: duplicate token key %q", domain.ErrValidation, id)
+		}
+		seen[id] = struct{}{}
+		keys = append(keys, Key{ID: id, Secret: []byte(secret)})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no token keys", domain.ErrValidation)
+	}
+	return keys, nil
+}
+
+// Signer implements port.TokenSigner with HMAC-SHA256. A token is
+//
+//	servingID.campaignID.creativeID.issuedAt[.purpose].keyID.signature
+//
+// where issuedAt is a Unix time, purpose is left out for ad tokens and
+// signature is the unpadded base64url MAC of everything before it. Tokens
+// are signed with the current key. Previous keys verify tokens until the
+// grace period after the rotation has passed, so ads served just before a
+// rotation keep working.
+type Signer struct {
+	current  Key
+	previous []Key
+	ttl      time.Duration
+	graceEnd time.Time
+	now      func() time.Time
+}
+
+// NewSigner creates a signer from keys, the first of which signs. Tokens
+// are valid for ttl after they were issued. The remaining keys are accepted
+// until grace after rotatedAt, the time the current key was introduced.
+func NewSigner(keys []Key, ttl time.Duration, rotatedAt time.Time, grace time.Duration, now func() time.Time) *Signer {
+	return &Signer{
+		current:  keys[0],
+		previous: keys[1:],
+		ttl:      ttl,
+		graceEnd: rotatedAt.Add(grace),
+		now:      now,
+	}
+}
+
+// Sign returns the token for claims signed with the current key.
+func (s *Signer) Sign(claims domain.TokenClaims) string {
+	parts := []string{
+		claims.ServingID,
+		strconv.FormatInt(claims.CampaignID, 10),
+		strconv.FormatInt(claims.CreativeID, 10),
+		strconv.FormatInt(claims.IssuedAt.Unix(), 10),
+	}
+	if claims.Purpose != domain.TokenPurposeAd {
+		parts = append(parts, string(claims.Purpose))
+	}
+	payload := strings.Join(append(parts, s.current.ID), ".")
+	return payload + "." + sign(s.current, payload)
+}
+
+// Verify checks the signature and age of token and returns its claims.
+func (s *Signer) Verify(token string) (domain.TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 6 && len(parts) != 7 {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+	key, ok := s.key(parts[len(parts)-2])
+	if !ok {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+	payload := token[:strings.LastIndexByte(token, '.')]
+	if !hmac.Equal([]byte(parts[len(parts)-1]), []byte(sign(key, payload))) {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+	var purpose domain.TokenPurpose
+	if len(parts) == 7 {
+		if purpose = domain.TokenPurpose(parts[4]); purpose == domain.TokenPurposeAd {
+			return domain.TokenClaims{}, domain.ErrInvalidToken
+		}
+	}
+
+	campaignID, err1 := strconv.ParseInt(parts[1], 10, 64)
+	creativeID, err2 := strconv.ParseInt(parts[2], 10, 64)
+	issued, err3 := strconv.ParseInt(parts[3], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || parts[0] == "" {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+	claims := domain.TokenClaims{
+		ServingID:  parts[0],
+		CampaignID: campaignID,
+		CreativeID: creativeID,
+		IssuedAt:   time.Unix(issued, 0).UTC(),
+		Purpose:    purpose,
+	}
+
+	now := s.now()
+	if claims.IssuedAt.After(now.Add(clockSkew)) || now.Sub(claims.IssuedAt) > s.ttl {
+		return domain.TokenClaims{}, fmt.Errorf("%w: expired", domain.ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// key returns the key with id that may verify tokens now.
+func (s *Signer) key(id string) (Key, bool) {
+	if id == s.current.ID {
+		return s.current, true
+	}
+	if !s.now().Before(s.graceEnd) {
+		return Key{}, false
+	}
+	for _, k := range s.previous {
+		if k.ID == id {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// sign returns the base64url MAC of payload under key.
+func sign(key Key, payload string) string {
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// validKeyID reports whether id is a non-empty run of URL safe characters
+// that cannot be confused with the token separator.
+func validKeyID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
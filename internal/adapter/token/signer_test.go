@@ -0,0 +1,104 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+var (
+	oldKey = Key{ID: "k1", Secret: []byte("0123456789abcdef-old")}
+	newKey = Key{ID: "k2", Secret: []byte("0123456789abcdef-new")}
+)
+
+// TestSignVerify ensures a signed token round-trips its claims.
+func TestSignVerify(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSigner([]Key{newKey}, time.Hour, time.Time{}, 0, func() time.Time { return now })
+
+	claims := domain.TokenClaims{ServingID: "3f1c-9a", CampaignID: 7, CreativeID: 42, IssuedAt: now}
+	got, err := s.Verify(s.Sign(claims))
+	if err != nil {
+		t.Fatalf("Verify error: %v", err)
+	}
+	if got != claims {
+		t.Fatalf("claims = %+v, want %+v", got, claims)
+	}
+
+	claims.Purpose = domain.TokenPurposeNotice
+	notice := s.Sign(claims)
+	if got, err = s.Verify(notice); err != nil || got != claims {
+		t.Fatalf("notice claims = %+v, %v, want %+v", got, err, claims)
+	}
+	// the purpose is signed: stripping it does not yield an ad token
+	stripped := strings.Replace(notice, ".notice.", ".", 1)
+	if _, err = s.Verify(stripped); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("stripped notice token: expected invalid token, got %v", err)
+	}
+}
+
+// TestVerifyRejects ensures malformed, forged and expired tokens are rejected.
+func TestVerifyRejects(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSigner([]Key{newKey}, time.Hour, time.Time{}, 0, func() time.Time { return now })
+	valid := s.Sign(domain.TokenClaims{ServingID: "abc", CampaignID: 1, CreativeID: 2, IssuedAt: now})
+	forged := NewSigner([]Key{{ID: "k2", Secret: []byte("fedcba9876543210-new")}}, time.Hour, time.Time{}, 0,
+		func() time.Time { return now })
+
+	for name, token := range map[string]string{
+		"empty":     "",
+		"uuid":      "fbee64a8-adab-447d-a3ea-79add27f8a86",
+		"tampered":  strings.Replace(valid, "abc.1.", "abc.9.", 1),
+		"forged":    forged.Sign(domain.TokenClaims{ServingID: "abc", CampaignID: 1, CreativeID: 2, IssuedAt: now}),
+		"expired":   s.Sign(domain.TokenClaims{ServingID: "abc", IssuedAt: now.Add(-2 * time.Hour)}),
+		"future":    s.Sign(domain.TokenClaims{ServingID: "abc", IssuedAt: now.Add(time.Hour)}),
+		"short sig": valid[:len(valid)-1],
+	} {
+		if _, err := s.Verify(token); !errors.Is(err, domain.ErrInvalidToken) {
+			t.Errorf("%s: expected invalid token, got %v", name, err)
+		}
+	}
+}
+
+// TestKeyRotation ensures the previous key verifies tokens only during the grace period.
+func TestKeyRotation(t *testing.T) {
+	rotatedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := rotatedAt.Add(time.Hour)
+	clock := func() time.Time { return now }
+
+	before := NewSigner([]Key{oldKey}, 24*time.Hour, time.Time{}, 0, clock)
+	token := before.Sign(domain.TokenClaims{ServingID: "abc", IssuedAt: rotatedAt.Add(-time.Minute)})
+
+	after := NewSigner([]Key{newKey, oldKey}, 24*time.Hour, rotatedAt, 2*time.Hour, clock)
+	if _, err := after.Verify(token); err != nil {
+		t.Fatalf("expected previous key accepted during grace, got %v", err)
+	}
+	if !strings.Contains(after.Sign(domain.TokenClaims{ServingID: "abc", IssuedAt: now}), ".k2.") {
+		t.Fatalf("expected tokens signed with the current key")
+	}
+
+	now = rotatedAt.Add(3 * time.Hour)
+	if _, err := after.Verify(token); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("expected previous key rejected after grace, got %v", err)
+	}
+}
+
+// TestParseKeys checks parsing of the configured key set.
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("k2:0123456789abcdef-new, k1:0123456789abcdef-old")
+	if err != nil {
+		t.Fatalf("ParseKeys error: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "k2" || string(keys[1].Secret) != "0123456789abcdef-old" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	for _, s := range []string{"", "k1", "k1:short", "k.1:0123456789abcdef", "k1:0123456789abcdef,k1:0123456789abcdef"} {
+		if _, err := ParseKeys(s); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("%q: expected validation error, got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// AdRepository decorates a port.AdRepository with a span around every
+// call. Queries issued by the call are traced as its children when the pool
+// has a QueryTracer.
+type AdRepository struct {
+	next   port.AdRepository
+	tracer trace.Tracer
+}
+
+// NewAdRepository wraps next with spans from tp.
+func NewAdRepository(next port.AdRepository, tp trace.TracerProvider) *AdRepository {
+	return &AdRepository{next: next, tracer: tp.Tracer(instrumentation)}
+}
+
+func (r *AdRepository) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, "AdRepository."+method, trace.WithAttributes(attrs...))
+}
+
+// GetEligibleCreatives implements port.AdRepository.
+func (r *AdRepository) GetEligibleCreatives(ctx context.Context, user domain.UserContext) ([]port.CreativeCandidate, error) {
+	ctx, span := r.start(ctx, "GetEligibleCreatives")
+	candidates, err := r.next.GetEligibleCreatives(ctx, user)
+	span.SetAttributes(attribute.Int("ad.candidates", len(candidates)))
+	end(span, err)
+	return candidates, err
+}
+
+// ReserveImpression implements port.AdRepository.
+func (r *AdRepository) ReserveImpression(
+	ctx context.Context,
+	imp domain.Impression,
+	cpmPrice int64,
+	expiresAt time.Time,
+) error {
+	ctx, span := r.start(ctx, "ReserveImpression", attribute.Int64("ad.campaign_id", imp.CampaignID))
+	err := r.next.ReserveImpression(ctx, imp, cpmPrice, expiresAt)
+	end(span, err)
+	return err
+}
+
+// CommitImpression implements port.AdRepository.
+func (r *AdRepository) CommitImpression(ctx context.Context, token string) (*domain.Impression, error) {
+	ctx, span := r.start(ctx, "CommitImpression")
+	imp, err := r.next.CommitImpression(ctx, token)
+	end(span, err)
+	return imp, err
+}
+
+// SettleImpression implements port.AdRepository.
+func (r *AdRepository) SettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	ctx, span := r.start(ctx, "SettleImpression")
+	imp, err := r.next.SettleImpression(ctx, token, cpmPrice)
+	end(span, err)
+	return imp, err
+}
+
+// ResettleImpression implements port.AdRepository.
+func (r *AdRepository) ResettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	ctx, span := r.start(ctx, "ResettleImpression")
+	imp, err := r.next.ResettleImpression(ctx, token, cpmPrice)
+	end(span, err)
+	return imp, err
+}
+
+// ReleaseImpression implements port.AdRepository.
+func (r *AdRepository) ReleaseImpression(ctx context.Context, token string) (bool, error) {
+	ctx, span := r.start(ctx, "ReleaseImpression")
+	released, err := r.next.ReleaseImpression(ctx, token)
+	span.SetAttributes(attribute.Bool("ad.released", released))
+	end(span, err)
+	return released, err
+}
+
+// ReleaseExpiredReservations implements port.AdRepository.
+func (r *AdRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := r.start(ctx, "ReleaseExpiredReservations")
+	released, err := r.next.ReleaseExpiredReservations(ctx, now)
+	span.SetAttributes(attribute.Int64("ad.released", released))
+	end(span, err)
+	return released, err
+}
+
+// CreateClickAndDeductBudget implements port.AdRepository.
+func (r *AdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcPrice int64) error {
+	ctx, span := r.start(ctx, "CreateClickAndDeductBudget", attribute.Int64("ad.campaign_id", click.CampaignID))
+	err := r.next.CreateClickAndDeductBudget(ctx, click, cpcPrice)
+	end(span, err)
+	return err
+}
+
+// CreatePlaybackEvent implements port.AdRepository.
+func (r *AdRepository) CreatePlaybackEvent(ctx context.Context, event domain.PlaybackEvent) error {
+	ctx, span := r.start(ctx, "CreatePlaybackEvent", attribute.String("ad.event", string(event.Event)))
+	err := r.next.CreatePlaybackEvent(ctx, event)
+	end(span, err)
+	return err
+}
+
+// GetStats implements port.AdRepository.
+func (r *AdRepository) GetStats(ctx context.Context, req port.StatsReq) (*port.StatsResp, error) {
+	ctx, span := r.start(ctx, "GetStats")
+	stats, err := r.next.GetStats(ctx, req)
+	end(span, err)
+	return stats, err
+}
+
+// GetStatsTimeSeries implements port.AdRepository.
+func (r *AdRepository) GetStatsTimeSeries(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error) {
+	ctx, span := r.start(ctx, "GetStatsTimeSeries")
+	buckets, err := r.next.GetStatsTimeSeries(ctx, req)
+	end(span, err)
+	return buckets, err
+}
+
+// GetGroupedStats implements port.AdRepository.
+func (r *AdRepository) GetGroupedStats(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error) {
+	ctx, span := r.start(ctx, "GetGroupedStats")
+	rows, err := r.next.GetGroupedStats(ctx, req)
+	end(span, err)
+	return rows, err
+}
+
+// FindImpressionByToken implements port.AdRepository.
+func (r *AdRepository) FindImpressionByToken(ctx context.Context, token string) (*domain.Impression, error) {
+	ctx, span := r.start(ctx, "FindImpressionByToken")
+	imp, err := r.next.FindImpressionByToken(ctx, token)
+	end(span, err)
+	return imp, err
+}
+
+// GetCreative implements port.AdRepository.
+func (r *AdRepository) GetCreative(ctx context.Context, id int64) (*domain.Creative, error) {
+	ctx, span := r.start(ctx, "GetCreative", attribute.Int64("ad.creative_id", id))
+	creative, err := r.next.GetCreative(ctx, id)
+	end(span, err)
+	return creative, err
+}
+
+// GetCampaign implements port.AdRepository.
+func (r *AdRepository) GetCampaign(ctx context.Context, id int64) (*domain.Campaign, error) {
+	ctx, span := r.start(ctx, "GetCampaign", attribute.Int64("ad.campaign_id", id))
+	campaign, err := r.next.GetCampaign(ctx, id)
+	end(span, err)
+	return campaign, err
+}
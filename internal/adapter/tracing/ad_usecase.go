@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// AdUseCase decorates a port.AdUseCase with a span around ad selection.
+// Methods without spans are passed through.
+type AdUseCase struct {
+	port.AdUseCase
+
+	tracer trace.Tracer
+}
+
+// NewAdUseCase wraps next with spans from tp.
+func NewAdUseCase(next port.AdUseCase, tp trace.TracerProvider) *AdUseCase {
+	return &AdUseCase{AdUseCase: next, tracer: tp.Tracer(instrumentation)}
+}
+
+// RequestAd traces ad selection. The span tells whether the request was
+// filled and with which creative.
+func (s *AdUseCase) RequestAd(ctx context.Context, user domain.UserContext) (*port.AdResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "AdUseCase.RequestAd", trace.WithAttributes(
+		attribute.String("ad.placement", user.Placement),
+	))
+	resp, err := s.AdUseCase.RequestAd(ctx, user)
+	if err == nil {
+		span.SetAttributes(attribute.Bool("ad.filled", resp != nil))
+		if resp != nil {
+			span.SetAttributes(
+				attribute.Int64("ad.campaign_id", resp.CampaignID),
+				attribute.Int64("ad.creative_id", resp.CreativeID),
+			)
+		}
+	}
+	end(span, err)
+	return resp, err
+}
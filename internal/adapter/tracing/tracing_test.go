@@ -0,0 +1,160 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+func newRecorder() (*tracetest.SpanRecorder, trace.TracerProvider) {
+	rec := tracetest.NewSpanRecorder()
+	return rec, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+}
+
+// spansByName indexes ended spans by name and fails on duplicates.
+func spansByName(t *testing.T, rec *tracetest.SpanRecorder) map[string]sdktrace.ReadOnlySpan {
+	t.Helper()
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range rec.Ended() {
+		if _, ok := spans[s.Name()]; ok {
+			t.Fatalf("span %q recorded twice", s.Name())
+		}
+		spans[s.Name()] = s
+	}
+	return spans
+}
+
+func attr(s sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range s.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestRequestAdSpans checks that repository calls and their queries are
+// nested under the use case span and that failures mark the span.
+func TestRequestAdSpans(t *testing.T) {
+	rec, tp := newRecorder()
+	queries := NewQueryTracer(tp)
+	next := mocks.NewMockAdRepository(t)
+	next.EXPECT().GetEligibleCreatives(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, _ domain.UserContext) ([]port.CreativeCandidate, error) {
+			ctx = queries.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "select * from creatives"})
+			queries.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 2")})
+			return make([]port.CreativeCandidate, 2), nil
+		})
+	next.EXPECT().ReserveImpression(mock.Anything, mock.Anything, int64(100), mock.Anything).
+		Return(port.ErrInsufficientBudget)
+	repo := NewAdRepository(next, tp)
+
+	uc := mocks.NewMockAdUseCase(t)
+	uc.EXPECT().RequestAd(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, user domain.UserContext) (*port.AdResponse, error) {
+			if _, err := repo.GetEligibleCreatives(ctx, user); err != nil {
+				return nil, err
+			}
+			_ = repo.ReserveImpression(ctx, domain.Impression{CampaignID: 7}, 100, time.Now())
+			return &port.AdResponse{CampaignID: 8, CreativeID: 9}, nil
+		})
+	svc := NewAdUseCase(uc, tp)
+
+	if _, err := svc.RequestAd(context.Background(), domain.UserContext{Placement: "pre-roll"}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := spansByName(t, rec)
+	if len(spans) != 4 {
+		t.Fatalf("recorded %d spans, want 4", len(spans))
+	}
+	root := spans["AdUseCase.RequestAd"]
+	if root == nil || root.Parent().IsValid() {
+		t.Fatalf("use case span missing or not a root: %v", root)
+	}
+	if got := attr(root, "ad.creative_id").AsInt64(); got != 9 {
+		t.Errorf("ad.creative_id = %d, want 9", got)
+	}
+	if !attr(root, "ad.filled").AsBool() {
+		t.Error("ad.filled = false, want true")
+	}
+	for name, parent := range map[string]string{
+		"AdRepository.GetEligibleCreatives": "AdUseCase.RequestAd",
+		"AdRepository.ReserveImpression":    "AdUseCase.RequestAd",
+		"SELECT":                            "AdRepository.GetEligibleCreatives",
+	} {
+		s := spans[name]
+		if s == nil {
+			t.Fatalf("span %q not recorded", name)
+		}
+		if s.Parent().SpanID() != spans[parent].SpanContext().SpanID() {
+			t.Errorf("parent of %q is not %q", name, parent)
+		}
+	}
+	if got := attr(spans["AdRepository.GetEligibleCreatives"], "ad.candidates").AsInt64(); got != 2 {
+		t.Errorf("ad.candidates = %d, want 2", got)
+	}
+	if s := spans["AdRepository.ReserveImpression"]; s.Status().Code != codes.Error || len(s.Events()) != 1 {
+		t.Errorf("reservation span status = %v with %d events, want error", s.Status(), len(s.Events()))
+	}
+	if root.Status().Code == codes.Error {
+		t.Error("use case span marked as failed")
+	}
+}
+
+// TestQueryTracer checks the attributes of query and COPY spans.
+func TestQueryTracer(t *testing.T) {
+	rec, tp := newRecorder()
+	queries := NewQueryTracer(tp)
+	ctx := context.Background()
+
+	const sql = "\n\tupdate campaigns SET spent = spent + $1 WHERE id = $2"
+	qctx := queries.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{100, 7}})
+	queries.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{Err: errors.New("deadlock detected")})
+	cctx := queries.TraceCopyFromStart(ctx, nil, pgx.TraceCopyFromStartData{TableName: pgx.Identifier{"stats_hourly_reach"}})
+	queries.TraceCopyFromEnd(cctx, nil, pgx.TraceCopyFromEndData{CommandTag: pgconn.NewCommandTag("COPY 3")})
+
+	spans := spansByName(t, rec)
+	update := spans["UPDATE"]
+	if update == nil {
+		t.Fatalf("update span not recorded: %v", spans)
+	}
+	if update.SpanKind() != trace.SpanKindClient {
+		t.Errorf("kind = %v, want client", update.SpanKind())
+	}
+	if got := attr(update, "db.query.text").AsString(); got != sql {
+		t.Errorf("db.query.text = %q", got)
+	}
+	if got := attr(update, "db.system.name").AsString(); got != "postgresql" {
+		t.Errorf("db.system.name = %q", got)
+	}
+	if update.Status().Code != codes.Error {
+		t.Errorf("status = %v, want error", update.Status())
+	}
+	for _, kv := range update.Attributes() {
+		if kv.Value.Emit() == "100" {
+			t.Errorf("query argument recorded as %s", kv.Key)
+		}
+	}
+	copySpan := spans["COPY stats_hourly_reach"]
+	if copySpan == nil {
+		t.Fatalf("copy span not recorded: %v", spans)
+	}
+	if got := attr(copySpan, "db.rows_affected").AsInt64(); got != 3 {
+		t.Errorf("db.rows_affected = %d, want 3", got)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -16,78 +19,179 @@ import (
 type AdUseCase struct {
 	repo port.AdRepository
 
+	// pacer throttles campaigns that spend their daily budget too fast. A
+	// nil pacer lets every candidate compete.
+	pacer port.Pacer
+
+	// capper enforces frequency caps. A nil capper serves users without
+	// limits.
+	capper port.FrequencyCapper
+
+	// ctr estimates click-through rates of CPC candidates. A nil estimator
+	// ranks them with defaultCTR.
+	ctr port.CTREstimator
+
+	// signer signs the tokens of impression, tracking and click URLs. A nil
+	// signer uses the bare serving ID as token.
+	signer port.TokenSigner
+
+	// reserves are the auction floor prices per placement.
+	reserves domain.ReservePrices
+
+	// reservationTTL is how long a selected ad waits for its impression
+	// beacon before the reserved budget is released.
+	reservationTTL time.Duration
+
+	// now returns the current time.
+	now func() time.Time
+
 	// defaultCTR is the estimated click‑through rate used for eCPM
 	// calculations when no prior data exists. It is expressed as a
 	// fraction in the range [0,1].
 	defaultCTR float64
 }
 
+// AdOption configures optional collaborators of an AdUseCase.
+type AdOption func(u *AdUseCase)
+
+// WithPacer enables budget pacing during ad selection.
+func WithPacer(p port.Pacer) AdOption {
+	return func(u *AdUseCase) { u.pacer = p }
+}
+
+// WithFrequencyCapper enables frequency capping during ad selection.
+func WithFrequencyCapper(c port.FrequencyCapper) AdOption {
+	return func(u *AdUseCase) { u.capper = c }
+}
+
+// WithCTREstimator ranks CPC candidates by their estimated historical CTR.
+func WithCTREstimator(e port.CTREstimator) AdOption {
+	return func(u *AdUseCase) { u.ctr = e }
+}
+
+// WithTokenSigner signs the tokens embedded in ad URLs and rejects
+// forged or expired tokens before they reach the repository.
+func WithTokenSigner(s port.TokenSigner) AdOption {
+	return func(u *AdUseCase) { u.signer = s }
+}
+
+// WithReservePrices sets the minimum eCPM a candidate must bid to win an
+// auction for a placement.
+func WithReservePrices(r domain.ReservePrices) AdOption {
+	return func(u *AdUseCase) { u.reserves = r }
+}
+
+// WithReservationTTL sets how long budget reserved for a selected ad is
+// held until the player confirms the impression.
+func WithReservationTTL(ttl time.Duration) AdOption {
+	return func(u *AdUseCase) { u.reservationTTL = ttl }
+}
+
 // NewAdUseCase creates a new usecase with the provided repository. The
-// defaultCTR is set to a reasonable small value.
-func NewAdUseCase(repo port.AdRepository) *AdUseCase {
-	return &AdUseCase{repo: repo, defaultCTR: 0.01}
+// defaultCTR is set to a reasonable small value and reservations are held
+// for five minutes.
+func NewAdUseCase(repo port.AdRepository, opts ...AdOption) *AdUseCase {
+	u := &AdUseCase{repo: repo, defaultCTR: 0.01, reservationTTL: 5 * time.Minute, now: time.Now}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
-// RequestAd selects a suitable ad for the given user context, creates an
-// impression and deducts CPM budget. It returns nil when no creative
-// matches the targeting or budgets are exhausted. An error is returned on
-// repository failures.
+// RequestAd selects a suitable ad for the given user context in a
+// second-price auction and reserves the clearing price from CPM budgets
+// until ConfirmImpression bills the impression. CPC winners are charged
+// their clearing price per click instead. It returns nil when no creative
+// matches the targeting, reaches the reserve price or has budget left. An
+// error is returned on repository failures.
 func (u *AdUseCase) RequestAd(ctx context.Context, user domain.UserContext) (*port.AdResponse, error) {
+	user.Normalize()
 	candidates, err := u.repo.GetEligibleCreatives(ctx, user)
 	if err != nil {
 		return nil, err
 	}
+	if u.pacer != nil {
+		candidates = slices.DeleteFunc(candidates, func(c port.CreativeCandidate) bool {
+			return !u.pacer.Allow(c.Campaign)
+		})
+	}
+	if u.capper != nil {
+		candidates, err = u.capper.Filter(ctx, user.UserID, candidates)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if len(candidates) == 0 {
 		return nil, nil
 	}
 
+	bids := make([]float64, len(candidates))
 	for i := range candidates {
-		candidates[i].Score = u.computeScore(&candidates[i].Campaign)
+		candidates[i].Score = u.computeScore(&candidates[i])
+		bids[i] = candidates[i].Score
 	}
+	reserve := max(u.reserves.For(user.Placement), user.Floor)
 
-	// пока есть кандидаты, пытаемся выбрать лучший и списать бюджет
+	// пока есть кандидаты, проводим аукцион и пытаемся списать бюджет
 	for len(candidates) > 0 {
-		// ищем кандидата с максимальным score
-		bestIndex := -1
-		bestScore := float64(-1)
-		for i := range candidates {
-			if candidates[i].Score > bestScore {
-				bestScore = candidates[i].Score
-				bestIndex = i
-			}
-		}
-		if bestIndex < 0 {
+		result, ok := domain.RunAuction(bids, reserve)
+		if !ok {
 			return nil, nil
 		}
+		chosen := candidates[result.Winner]
 
-		chosen := candidates[bestIndex]
-
-		// генерим токен и создаём impression
-		token := uuid.NewString()
+		// генерим токен и резервируем impression
+		servingID := uuid.NewString()
 		imp := domain.Impression{
-			Token:      token,
+			Token:      servingID,
 			CreativeID: chosen.Creative.ID,
 			CampaignID: chosen.Campaign.ID,
 			UserID:     user.UserID,
+			Language:   user.Language,
+			Geo:        user.Geo,
+			Placement:  user.Placement,
+		}
+		var cpmPrice int64
+		if float64(chosen.Campaign.CPMBid) >= chosen.Score {
+			cpmPrice = int64(math.Ceil(result.Price))
+		} else {
+			imp.ClickCost = u.clickPrice(&chosen, result.Price)
 		}
 
-		err = u.repo.CreateImpressionAndDeductBudget(ctx, imp, chosen.Campaign.CPMBid)
+		err = u.repo.ReserveImpression(ctx, imp, cpmPrice, u.now().Add(u.reservationTTL))
 		if err != nil {
 			if errors.Is(err, port.ErrInsufficientBudget) {
-				// выкидываем этого кандидата из слайса
-				// TODO написать алгоритм получше
-				candidates = append(candidates[:bestIndex], candidates[bestIndex+1:]...)
+				// выкидываем этого кандидата и переигрываем аукцион
+				candidates = slices.Delete(candidates, result.Winner, result.Winner+1)
+				bids = slices.Delete(bids, result.Winner, result.Winner+1)
 				continue
 			}
 			return nil, err
 		}
+		// the reservation counts towards the caps right away, so a user
+		// cannot exceed them by not confirming impressions
+		if u.capper != nil {
+			u.capper.Record(ctx, imp)
+		}
 
+		token := u.issueToken(imp)
 		clickURL := fmt.Sprintf("/api/v1/ad/click/%s", token)
+		tracking := make(map[domain.TrackingEvent]string, len(domain.TrackingEvents))
+		for _, event := range domain.TrackingEvents {
+			tracking[event] = fmt.Sprintf("/api/v1/ad/track/%s/%s", token, event)
+		}
 		return &port.AdResponse{
-			CreativeID: chosen.Creative.ID,
-			Duration:   chosen.Creative.Duration,
-			VideoURL:   chosen.Creative.VideoURL,
-			ClickURL:   clickURL,
+			ServingID:     servingID,
+			Token:         token,
+			CampaignID:    chosen.Campaign.ID,
+			CreativeID:    chosen.Creative.ID,
+			Price:         result.Price,
+			Title:         chosen.Creative.Title,
+			Duration:      chosen.Creative.Duration,
+			VideoURL:      chosen.Creative.VideoURL,
+			ClickURL:      clickURL,
+			ImpressionURL: fmt.Sprintf("/api/v1/ad/impression/%s", token),
+			TrackingURLs:  tracking,
 		}, nil
 	}
 
@@ -95,18 +199,123 @@ func (u *AdUseCase) RequestAd(ctx context.Context, user domain.UserContext) (*po
 	return nil, nil
 }
 
-// RegisterClick records a click event by token and deducts CPC budget if
-// applicable. It returns the landing URL for redirection.
+// ConfirmImpression bills the impression reserved under token when the
+// player reports it was shown. Repeated confirmations of a billed
+// impression succeed without charging again; unknown tokens and expired
+// reservations result in ErrNotFound.
+func (u *AdUseCase) ConfirmImpression(ctx context.Context, token string) error {
+	servingID, err := u.servingID(token, domain.TokenPurposeAd)
+	if err != nil {
+		return err
+	}
+	imp, err := u.impression(ctx, servingID)
+	if err != nil {
+		return err
+	}
+	if imp == nil {
+		return port.ErrNotFound
+	}
+	return nil
+}
+
+// NoticeToken returns the notice token of the impression of the ad token.
+// Without a signer tokens are bare serving IDs and cannot be told apart.
+func (u *AdUseCase) NoticeToken(token string) (string, error) {
+	if u.signer == nil {
+		return token, nil
+	}
+	claims, err := u.signer.Verify(token)
+	if err != nil {
+		return "", err
+	}
+	if claims.Purpose != domain.TokenPurposeAd {
+		return "", domain.ErrInvalidToken
+	}
+	claims.Purpose = domain.TokenPurposeNotice
+	return u.signer.Sign(claims), nil
+}
+
+// SettleImpression bills the impression reserved under the notice token at
+// the exchange clearing price cpmPrice. An impression a click or tracking
+// event billed at the reserved cost before the notice is billed down to
+// cpmPrice. Repeated notices of a settled impression succeed without
+// changing its cost; unknown tokens and expired reservations result in
+// ErrNotFound.
+func (u *AdUseCase) SettleImpression(ctx context.Context, token string, cpmPrice int64) error {
+	servingID, err := u.servingID(token, domain.TokenPurposeNotice)
+	if err != nil {
+		return err
+	}
+	imp, err := u.repo.SettleImpression(ctx, servingID, cpmPrice)
+	if err != nil || imp != nil {
+		return err
+	}
+	imp, err = u.repo.ResettleImpression(ctx, servingID, cpmPrice)
+	if err != nil || imp != nil {
+		return err
+	}
+	imp, err = u.repo.FindImpressionByToken(ctx, servingID)
+	if err != nil {
+		return err
+	}
+	if imp == nil {
+		return port.ErrNotFound
+	}
+	return nil
+}
+
+// ReleaseImpression returns the budget reserved under the notice token to
+// the campaign. Billed impressions are kept; their tokens, like unknown
+// ones, result in ErrNotFound.
+func (u *AdUseCase) ReleaseImpression(ctx context.Context, token string) error {
+	servingID, err := u.servingID(token, domain.TokenPurposeNotice)
+	if err != nil {
+		return err
+	}
+	released, err := u.repo.ReleaseImpression(ctx, servingID)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return port.ErrNotFound
+	}
+	return nil
+}
+
+// impression returns the billed impression of servingID. A click or
+// tracking event may arrive before the impression beacon, or the beacon may
+// be lost, so an unexpired reservation is committed by whichever event
+// comes first. It returns nil, nil for unknown tokens and expired
+// reservations.
+func (u *AdUseCase) impression(ctx context.Context, servingID string) (*domain.Impression, error) {
+	imp, err := u.repo.CommitImpression(ctx, servingID)
+	if err != nil || imp != nil {
+		return imp, err
+	}
+	return u.repo.FindImpressionByToken(ctx, servingID)
+}
+
+// ReleaseExpiredReservations returns the budget of reservations that were
+// not confirmed in time to their campaigns. It returns the number of
+// released reservations.
+func (u *AdUseCase) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	return u.repo.ReleaseExpiredReservations(ctx, now)
+}
+
+// RegisterClick records a click event by token and deducts the click price
+// fixed by the auction. A click on a still reserved impression bills the
+// impression first. It returns the landing URL for redirection.
 func (u *AdUseCase) RegisterClick(ctx context.Context, token string) (string, error) {
-	if token == "" {
-		return "", errors.New("empty token")
+	servingID, err := u.servingID(token, domain.TokenPurposeAd)
+	if err != nil {
+		return "", err
 	}
-	imp, err := u.repo.FindImpressionByToken(ctx, token)
+	imp, err := u.impression(ctx, servingID)
 	if err != nil {
 		return "", err
 	}
 	if imp == nil {
-		return "", errors.New("impression not found")
+		return "", fmt.Errorf("%w: impression", port.ErrNotFound)
 	}
 
 	cr, err := u.repo.GetCreative(ctx, imp.CreativeID)
@@ -126,38 +335,236 @@ func (u *AdUseCase) RegisterClick(ctx context.Context, token string) (string, er
 	}
 
 	click := domain.Click{
-		Token:        token,
+		Token:        servingID,
 		CreativeID:   imp.CreativeID,
 		CampaignID:   imp.CampaignID,
 		UserID:       imp.UserID,
 		ImpressionID: &imp.ID,
 	}
 
-	if err = u.repo.CreateClickAndDeductBudget(ctx, click, camp.CPCBid); err != nil {
+	// a repeated click redirects again without charging
+	err = u.repo.CreateClickAndDeductBudget(ctx, click, imp.ClickCost)
+	if err != nil && !errors.Is(err, port.ErrDuplicate) {
 		return "", err
 	}
 
 	return cr.LandingURL, nil
 }
 
-// GetStats returns aggregated stats for campaigns in a period.
+// TrackEvent records a player tracking event for the impression identified
+// by token. An event of a still reserved impression bills the impression
+// first.
+func (u *AdUseCase) TrackEvent(ctx context.Context, token string, event domain.TrackingEvent) error {
+	if !event.Valid() {
+		return fmt.Errorf("%w: unknown tracking event %q", domain.ErrValidation, event)
+	}
+	servingID, err := u.servingID(token, domain.TokenPurposeAd)
+	if err != nil {
+		return err
+	}
+	imp, err := u.impression(ctx, servingID)
+	if err != nil {
+		return err
+	}
+	if imp == nil {
+		return port.ErrNotFound
+	}
+	return u.repo.CreatePlaybackEvent(ctx, domain.PlaybackEvent{
+		Token:        servingID,
+		ImpressionID: imp.ID,
+		CreativeID:   imp.CreativeID,
+		CampaignID:   imp.CampaignID,
+		Event:        event,
+	})
+}
+
+// GetStats returns aggregated stats for campaigns in a period together
+// with the CTR estimate used for ranking, the campaign estimate when a
+// campaign is requested and the global one otherwise, and the quartile and
+// completion rates of the impressions.
 func (u *AdUseCase) GetStats(ctx context.Context, req port.StatsReq) (*port.StatsResp, error) {
-	return u.repo.GetStats(ctx, req)
+	stats, err := u.repo.GetStats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case u.ctr == nil:
+		stats.EstimatedCTR = u.defaultCTR
+	case req.CampaignID != nil:
+		stats.EstimatedCTR = u.ctr.CampaignCTR(*req.CampaignID)
+	default:
+		stats.EstimatedCTR = u.ctr.GlobalCTR()
+	}
+	if stats.Impressions > 0 {
+		rate := func(e domain.TrackingEvent) float64 {
+			return float64(stats.Events[e]) / float64(stats.Impressions)
+		}
+		stats.FirstQuartileRate = rate(domain.EventFirstQuartile)
+		stats.MidpointRate = rate(domain.EventMidpoint)
+		stats.ThirdQuartileRate = rate(domain.EventThirdQuartile)
+		stats.CompletionRate = rate(domain.EventComplete)
+	}
+	if stats.Reach > 0 {
+		stats.AvgFrequency = float64(stats.Impressions) / float64(stats.Reach)
+	}
+	return stats, nil
+}
+
+// GetGroupedStats returns stats per value of a dimension with CTR, eCPM and
+// eCPC derived from the counts and total cost.
+func (u *AdUseCase) GetGroupedStats(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error) {
+	if !req.GroupBy.Valid() {
+		return nil, fmt.Errorf("%w: unknown dimension %q", domain.ErrValidation, req.GroupBy)
+	}
+	rows, err := u.repo.GetGroupedStats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		row := &rows[i]
+		if row.Impressions > 0 {
+			row.CTR = float64(row.Clicks) / float64(row.Impressions)
+			row.ECPM = float64(row.Cost) * 1000 / float64(row.Impressions)
+		}
+		if row.Clicks > 0 {
+			row.ECPC = float64(row.Cost) / float64(row.Clicks)
+		}
+	}
+	return rows, nil
+}
+
+// maxStatsBuckets bounds the length of a stats time series, e.g. a little
+// over a year of hourly buckets.
+const maxStatsBuckets = 10000
+
+// GetStatsTimeSeries returns stats per hour or day of the period. The
+// repository reports the non-empty buckets; the gaps are filled with
+// zero buckets so charts get an evenly spaced series.
+func (u *AdUseCase) GetStatsTimeSeries(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error) {
+	if req.Location == nil {
+		req.Location = time.UTC
+	}
+	if req.Location == time.Local {
+		return nil, fmt.Errorf("%w: time zone must be an IANA name", domain.ErrValidation)
+	}
+	if req.Granularity != port.GranularityHour && req.Granularity != port.GranularityDay {
+		return nil, fmt.Errorf("%w: unknown granularity %q", domain.ErrValidation, req.Granularity)
+	}
+	if req.To.Before(req.From) {
+		return nil, fmt.Errorf("%w: period ends before it starts", domain.ErrValidation)
+	}
+	starts := bucketStarts(req.From, req.To, req.Granularity, req.Location)
+	if len(starts) > maxStatsBuckets {
+		return nil, fmt.Errorf("%w: period exceeds %d buckets", domain.ErrValidation, maxStatsBuckets)
+	}
+
+	found, err := u.repo.GetStatsTimeSeries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[int64]port.StatsBucket, len(found))
+	for _, b := range found {
+		byStart[b.Start.Unix()] = b
+	}
+	series := make([]port.StatsBucket, len(starts))
+	for i, start := range starts {
+		b := byStart[start.Unix()]
+		b.Start = start
+		series[i] = b
+	}
+	return series, nil
+}
+
+// bucketStarts returns the starts of the buckets covering [from, to] on
+// the wall clock of loc. It stops after maxStatsBuckets+1 starts so absurd
+// periods are not materialised.
+func bucketStarts(from, to time.Time, g port.Granularity, loc *time.Location) []time.Time {
+	from, to = from.In(loc), to.In(loc)
+	var (
+		start time.Time
+		next  func(time.Time) time.Time
+	)
+	if g == port.GranularityDay {
+		start = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+		next = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc) }
+	} else {
+		start = time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), 0, 0, 0, loc)
+		next = func(t time.Time) time.Time { return t.Add(time.Hour) }
+	}
+	var starts []time.Time
+	for t := start; !t.After(to) && len(starts) <= maxStatsBuckets; t = next(t) {
+		starts = append(starts, t)
+	}
+	return starts
+}
+
+// issueToken returns the token identifying imp in ad URLs.
+func (u *AdUseCase) issueToken(imp domain.Impression) string {
+	if u.signer == nil {
+		return imp.Token
+	}
+	return u.signer.Sign(domain.TokenClaims{
+		ServingID:  imp.Token,
+		CampaignID: imp.CampaignID,
+		CreativeID: imp.CreativeID,
+		IssuedAt:   u.now(),
+	})
+}
+
+// servingID verifies a token of purpose taken from an ad or notice URL and
+// returns the serving ID of the impression it refers to.
+func (u *AdUseCase) servingID(token string, purpose domain.TokenPurpose) (string, error) {
+	if token == "" {
+		return "", domain.ErrInvalidToken
+	}
+	if u.signer == nil {
+		return token, nil
+	}
+	claims, err := u.signer.Verify(token)
+	if err != nil {
+		return "", err
+	}
+	if claims.Purpose != purpose {
+		return "", domain.ErrInvalidToken
+	}
+	return claims.ServingID, nil
 }
 
 // computeScore returns a floating score for ranking candidate. For CPM
 // campaigns the score is simply the bid. For CPC campaigns the bid is
-// converted into an eCPM by multiplying with an estimated CTR and 1000.
-func (u *AdUseCase) computeScore(c *domain.Campaign) float64 {
-	var score float64
+// converted into an eCPM by multiplying with the estimated CTR of the
+// creative and 1000.
+func (u *AdUseCase) computeScore(cand *port.CreativeCandidate) float64 {
+	var (
+		c     = &cand.Campaign
+		score float64
+	)
 	if c.CPMBid > 0 {
 		score = float64(c.CPMBid)
 	}
 	if c.CPCBid > 0 {
-		cpcScore := float64(c.CPCBid) * u.defaultCTR * 1000.0
+		cpcScore := float64(c.CPCBid) * u.estimateCTR(cand) * 1000.0
 		if cpcScore > score {
 			score = cpcScore
 		}
 	}
 	return score
 }
+
+// estimateCTR returns the estimated CTR of the candidate creative.
+func (u *AdUseCase) estimateCTR(cand *port.CreativeCandidate) float64 {
+	if u.ctr == nil {
+		return u.defaultCTR
+	}
+	return u.ctr.CreativeCTR(cand.Campaign.ID, cand.Creative.ID)
+}
+
+// clickPrice converts an eCPM clearing price of a CPC winner into the
+// price of a click, never more than the campaign's CPC bid.
+func (u *AdUseCase) clickPrice(cand *port.CreativeCandidate, price float64) int64 {
+	ctr := u.estimateCTR(cand)
+	if ctr <= 0 {
+		return cand.Campaign.CPCBid
+	}
+	return min(int64(math.Ceil(price/(ctr*1000))), cand.Campaign.CPCBid)
+}
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -33,10 +35,11 @@ func TestAdSelection(t *testing.T) {
 		Return(creatives, nil)
 
 	repo.EXPECT().
-		CreateImpressionAndDeductBudget(
+		ReserveImpression(
 			mock.Anything,
 			mock.AnythingOfType("domain.Impression"),
 			int64(1000),
+			mock.Anything,
 		).
 		Return(nil)
 
@@ -74,12 +77,13 @@ func TestConcurrentBudget(t *testing.T) {
 		Return(candidates, nil)
 
 	repo.EXPECT().
-		CreateImpressionAndDeductBudget(
+		ReserveImpression(
 			mock.Anything,
 			mock.AnythingOfType("domain.Impression"),
 			int64(1000),
+			mock.Anything,
 		).
-		Run(func(ctx context.Context, imp domain.Impression, cpmBid int64) {
+		Run(func(ctx context.Context, imp domain.Impression, cpmBid int64, _ time.Time) {
 			mu.Lock()
 			defer mu.Unlock()
 
@@ -96,7 +100,8 @@ func TestConcurrentBudget(t *testing.T) {
 		}).
 		Return(nil)
 
-	svc := NewAdUseCase(repo)
+	// a reserve at the bid makes the sole bidder pay its full CPM
+	svc := NewAdUseCase(repo, WithReservePrices(domain.ReservePrices{Default: 1000}))
 
 	wg := sync.WaitGroup{}
 	count := 10
@@ -114,3 +119,669 @@ func TestConcurrentBudget(t *testing.T) {
 		t.Fatalf("unexpected budget after concurrency: got %d, want 90", budget)
 	}
 }
+
+// TestAdSelectionPacing ensures throttled campaigns do not compete.
+func TestAdSelectionPacing(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	pacer := mocks.NewMockPacer(t)
+
+	user := domain.UserContext{UserID: "u1"}
+	fast := domain.Campaign{ID: 1, CPMBid: 5000, PacingMode: domain.PacingEven}
+	slow := domain.Campaign{ID: 2, CPMBid: 1000, PacingMode: domain.PacingEven}
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, user).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1, Duration: 30}, Campaign: fast},
+			{Creative: domain.Creative{ID: 2, Duration: 30}, Campaign: slow},
+		}, nil)
+	pacer.EXPECT().Allow(fast).Return(false)
+	pacer.EXPECT().Allow(slow).Return(true)
+	// the sole remaining bidder clears at the reserve plus one unit
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(1), mock.Anything).
+		Return(nil)
+
+	svc := NewAdUseCase(repo, WithPacer(pacer))
+
+	resp, err := svc.RequestAd(context.Background(), user)
+	if err != nil {
+		t.Fatalf("RequestAd error: %v", err)
+	}
+	if resp == nil || resp.CreativeID != 2 {
+		t.Fatalf("expected creative 2, got %+v", resp)
+	}
+}
+
+// TestAdSelectionCTR ensures CPC candidates are ranked by their estimated CTR.
+func TestAdSelectionCTR(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	estimator := mocks.NewMockCTREstimator(t)
+
+	user := domain.UserContext{UserID: "u1"}
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, user).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPMBid: 1000}},
+			{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 2, CPCBid: 100}},
+		}, nil)
+	estimator.EXPECT().CreativeCTR(int64(2), int64(2)).Return(0.05)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(0), mock.Anything).
+		Return(nil)
+
+	svc := NewAdUseCase(repo, WithCTREstimator(estimator))
+
+	resp, err := svc.RequestAd(context.Background(), user)
+	if err != nil {
+		t.Fatalf("RequestAd error: %v", err)
+	}
+	if resp == nil || resp.CreativeID != 2 {
+		t.Fatalf("expected creative 2, got %+v", resp)
+	}
+}
+
+// TestStatsEstimatedCTR ensures stats expose the campaign or global CTR estimate.
+func TestStatsEstimatedCTR(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	estimator := mocks.NewMockCTREstimator(t)
+
+	campaignID := int64(7)
+	repo.EXPECT().GetStats(mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, port.StatsReq) (*port.StatsResp, error) {
+			return &port.StatsResp{Impressions: 10}, nil
+		})
+	estimator.EXPECT().CampaignCTR(campaignID).Return(0.02)
+	estimator.EXPECT().GlobalCTR().Return(0.03)
+
+	svc := NewAdUseCase(repo, WithCTREstimator(estimator))
+
+	stats, err := svc.GetStats(context.Background(), port.StatsReq{CampaignID: &campaignID})
+	if err != nil || stats.EstimatedCTR != 0.02 {
+		t.Fatalf("expected campaign estimate, got %+v (%v)", stats, err)
+	}
+	stats, err = svc.GetStats(context.Background(), port.StatsReq{})
+	if err != nil || stats.EstimatedCTR != 0.03 {
+		t.Fatalf("expected global estimate, got %+v (%v)", stats, err)
+	}
+}
+
+// TestAuctionClearingPrice ensures the winner pays the runner-up's eCPM plus one unit.
+func TestAuctionClearingPrice(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+
+	user := domain.UserContext{UserID: "u1", Placement: "PreRoll"}
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPMBid: 3000}},
+			{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 2, CPMBid: 2000}},
+			{Creative: domain.Creative{ID: 3}, Campaign: domain.Campaign{ID: 3, CPMBid: 900}},
+		}, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(2001), mock.Anything).
+		Return(nil)
+
+	svc := NewAdUseCase(repo, WithReservePrices(domain.ReservePrices{
+		ByPlacement: map[string]int64{domain.PlacementPreRoll: 1000},
+	}))
+
+	resp, err := svc.RequestAd(context.Background(), user)
+	if err != nil {
+		t.Fatalf("RequestAd error: %v", err)
+	}
+	if resp == nil || resp.CreativeID != 1 {
+		t.Fatalf("expected creative 1, got %+v", resp)
+	}
+}
+
+// TestAuctionReserve ensures no ad is served when every bid is below the placement reserve.
+func TestAuctionReserve(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPMBid: 900}},
+		}, nil)
+
+	svc := NewAdUseCase(repo, WithReservePrices(domain.ReservePrices{
+		ByPlacement: map[string]int64{domain.PlacementMidRoll: 1000},
+	}))
+
+	resp, err := svc.RequestAd(context.Background(), domain.UserContext{Placement: "mid-roll"})
+	if err != nil || resp != nil {
+		t.Fatalf("expected no-fill, got %+v (%v)", resp, err)
+	}
+}
+
+// TestAuctionRerunOnBudget ensures the auction is rerun without a winner that ran out of budget.
+func TestAuctionRerunOnBudget(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPMBid: 3000}},
+			{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 2, CPMBid: 2000}},
+			{Creative: domain.Creative{ID: 3}, Campaign: domain.Campaign{ID: 3, CPMBid: 1500}},
+		}, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.MatchedBy(func(imp domain.Impression) bool {
+			return imp.CampaignID == 1
+		}), int64(2001), mock.Anything).
+		Return(port.ErrInsufficientBudget)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.MatchedBy(func(imp domain.Impression) bool {
+			return imp.CampaignID == 2
+		}), int64(1501), mock.Anything).
+		Return(nil)
+
+	svc := NewAdUseCase(repo)
+
+	resp, err := svc.RequestAd(context.Background(), domain.UserContext{})
+	if err != nil {
+		t.Fatalf("RequestAd error: %v", err)
+	}
+	if resp == nil || resp.CreativeID != 2 {
+		t.Fatalf("expected creative 2, got %+v", resp)
+	}
+}
+
+// TestAuctionClickPrice ensures CPC winners are charged their clearing price per click.
+func TestAuctionClickPrice(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPCBid: 300}},
+			{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 2, CPMBid: 1999}},
+		}, nil)
+
+	var served domain.Impression
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(0), mock.Anything).
+		Run(func(_ context.Context, imp domain.Impression, _ int64, _ time.Time) { served = imp }).
+		Return(nil)
+
+	svc := NewAdUseCase(repo)
+
+	resp, err := svc.RequestAd(context.Background(), domain.UserContext{})
+	if err != nil || resp == nil || resp.CreativeID != 1 {
+		t.Fatalf("expected creative 1, got %+v (%v)", resp, err)
+	}
+	// eCPM 3000 at 1% CTR; clearing 2000 eCPM is 200 per click
+	if served.ClickCost != 200 {
+		t.Fatalf("ClickCost = %d, want 200", served.ClickCost)
+	}
+
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "t1").Return(&served, nil)
+	repo.EXPECT().GetCreative(mock.Anything, int64(1)).Return(&domain.Creative{ID: 1, LandingURL: "l1"}, nil)
+	repo.EXPECT().GetCampaign(mock.Anything, int64(1)).Return(&domain.Campaign{ID: 1, CPCBid: 300}, nil)
+	repo.EXPECT().
+		CreateClickAndDeductBudget(mock.Anything, mock.AnythingOfType("domain.Click"), int64(200)).
+		Return(nil)
+
+	if url, err := svc.RegisterClick(context.Background(), "t1"); err != nil || url != "l1" {
+		t.Fatalf("RegisterClick = %q, %v", url, err)
+	}
+}
+
+// TestRegisterClickRepeated ensures a duplicate click still redirects and
+// an unknown token is reported as not found.
+func TestRegisterClickRepeated(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	repo.EXPECT().CommitImpression(mock.Anything, "missing").Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "missing").Return(nil, nil)
+	if _, err := svc.RegisterClick(context.Background(), "missing"); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "t1").
+		Return(&domain.Impression{ID: 5, Token: "t1", CreativeID: 2, CampaignID: 3}, nil)
+	repo.EXPECT().GetCreative(mock.Anything, int64(2)).Return(&domain.Creative{ID: 2, LandingURL: "l2"}, nil)
+	repo.EXPECT().GetCampaign(mock.Anything, int64(3)).Return(&domain.Campaign{ID: 3}, nil)
+	repo.EXPECT().CreateClickAndDeductBudget(mock.Anything, mock.Anything, int64(0)).Return(port.ErrDuplicate)
+	if url, err := svc.RegisterClick(context.Background(), "t1"); err != nil || url != "l2" {
+		t.Fatalf("RegisterClick = %q, %v", url, err)
+	}
+}
+
+// TestRegisterClickBeforeBeacon ensures a click that arrives before the
+// impression beacon bills the reserved impression and is recorded.
+func TestRegisterClickBeforeBeacon(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	imp := &domain.Impression{ID: 5, Token: "t1", CreativeID: 2, CampaignID: 3, UserID: "u1", ClickCost: 200}
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(imp, nil).Once()
+	repo.EXPECT().GetCreative(mock.Anything, int64(2)).Return(&domain.Creative{ID: 2, LandingURL: "l2"}, nil)
+	repo.EXPECT().GetCampaign(mock.Anything, int64(3)).Return(&domain.Campaign{ID: 3}, nil)
+	repo.EXPECT().
+		CreateClickAndDeductBudget(mock.Anything, mock.MatchedBy(func(c domain.Click) bool {
+			return c.Token == "t1" && c.ImpressionID != nil && *c.ImpressionID == 5
+		}), int64(200)).
+		Return(nil)
+
+	if url, err := svc.RegisterClick(context.Background(), "t1"); err != nil || url != "l2" {
+		t.Fatalf("RegisterClick = %q, %v", url, err)
+	}
+
+	// the late beacon finds the impression already billed
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(nil, nil).Once()
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "t1").Return(imp, nil).Once()
+	if err := svc.ConfirmImpression(context.Background(), "t1"); err != nil {
+		t.Fatalf("ConfirmImpression error: %v", err)
+	}
+}
+
+// TestTrackEvent ensures tracking events are validated and attributed to the impression.
+func TestTrackEvent(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	if err := svc.TrackEvent(context.Background(), "t1", "rewind"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	repo.EXPECT().CommitImpression(mock.Anything, "missing").Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "missing").Return(nil, nil)
+	if err := svc.TrackEvent(context.Background(), "missing", domain.EventStart); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "t1").
+		Return(&domain.Impression{ID: 5, Token: "t1", CreativeID: 2, CampaignID: 3}, nil)
+	repo.EXPECT().CreatePlaybackEvent(mock.Anything, domain.PlaybackEvent{
+		Token: "t1", ImpressionID: 5, CreativeID: 2, CampaignID: 3, Event: domain.EventComplete,
+	}).Return(nil)
+	if err := svc.TrackEvent(context.Background(), "t1", domain.EventComplete); err != nil {
+		t.Fatalf("TrackEvent error: %v", err)
+	}
+}
+
+// TestStatsCompletionRates ensures quartile and completion rates are derived from impressions.
+func TestStatsCompletionRates(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	repo.EXPECT().GetStats(mock.Anything, mock.Anything).Return(&port.StatsResp{
+		Impressions: 200,
+		Events: map[domain.TrackingEvent]int64{
+			domain.EventStart:         180,
+			domain.EventFirstQuartile: 150,
+			domain.EventMidpoint:      120,
+			domain.EventThirdQuartile: 100,
+			domain.EventComplete:      90,
+		},
+	}, nil)
+
+	stats, err := NewAdUseCase(repo).GetStats(context.Background(), port.StatsReq{})
+	if err != nil {
+		t.Fatalf("GetStats error: %v", err)
+	}
+	if stats.FirstQuartileRate != 0.75 || stats.MidpointRate != 0.6 ||
+		stats.ThirdQuartileRate != 0.5 || stats.CompletionRate != 0.45 {
+		t.Fatalf("unexpected rates: %+v", stats)
+	}
+}
+
+// TestStatsAvgFrequency ensures the frequency is impressions per reached
+// user and stays zero without reach.
+func TestStatsAvgFrequency(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	repo.EXPECT().GetStats(mock.Anything, mock.Anything).Return(&port.StatsResp{Impressions: 300, Reach: 120}, nil).Once()
+	repo.EXPECT().GetStats(mock.Anything, mock.Anything).Return(&port.StatsResp{}, nil).Once()
+	svc := NewAdUseCase(repo)
+
+	stats, err := svc.GetStats(context.Background(), port.StatsReq{})
+	if err != nil || stats.AvgFrequency != 2.5 {
+		t.Fatalf("expected frequency 2.5, got %+v (%v)", stats, err)
+	}
+	stats, err = svc.GetStats(context.Background(), port.StatsReq{})
+	if err != nil || stats.AvgFrequency != 0 {
+		t.Fatalf("expected zero frequency, got %+v (%v)", stats, err)
+	}
+}
+
+// TestStatsTimeSeries ensures buckets follow the requested time zone and
+// gaps are filled with zero buckets.
+func TestStatsTimeSeries(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 0, 0, 0, 0, msk) }
+	repo := mocks.NewMockAdRepository(t)
+	repo.EXPECT().GetStatsTimeSeries(mock.Anything, mock.Anything).Return([]port.StatsBucket{
+		{Start: day(2).UTC(), Impressions: 10, Clicks: 1, Cost: 25},
+	}, nil).Once()
+
+	series, err := NewAdUseCase(repo).GetStatsTimeSeries(context.Background(), port.TimeSeriesReq{
+		StatsReq: port.StatsReq{
+			From: time.Date(2025, 5, 31, 22, 0, 0, 0, time.UTC),
+			To:   time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC),
+		},
+		Granularity: port.GranularityDay,
+		Location:    msk,
+	})
+	if err != nil {
+		t.Fatalf("GetStatsTimeSeries error: %v", err)
+	}
+	want := []port.StatsBucket{
+		{Start: day(1)},
+		{Start: day(2), Impressions: 10, Clicks: 1, Cost: 25},
+		{Start: day(3)},
+	}
+	if len(series) != len(want) {
+		t.Fatalf("got %d buckets, want %d: %+v", len(series), len(want), series)
+	}
+	for i := range want {
+		if !series[i].Start.Equal(want[i].Start) || series[i].Start.Location() != msk ||
+			series[i].Impressions != want[i].Impressions || series[i].Cost != want[i].Cost {
+			t.Errorf("bucket %d = %+v, want %+v", i, series[i], want[i])
+		}
+	}
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for name, req := range map[string]port.TimeSeriesReq{
+		"granularity": {StatsReq: port.StatsReq{From: from, To: from}, Granularity: "week"},
+		"reversed":    {StatsReq: port.StatsReq{From: from, To: from.Add(-time.Hour)}, Granularity: port.GranularityHour},
+		"too long":    {StatsReq: port.StatsReq{From: from, To: from.AddDate(2, 0, 0)}, Granularity: port.GranularityHour},
+		"local zone":  {StatsReq: port.StatsReq{From: from, To: from}, Granularity: port.GranularityDay, Location: time.Local},
+	} {
+		if _, err := NewAdUseCase(repo).GetStatsTimeSeries(context.Background(), req); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+// TestImpressionContext ensures impressions record the normalized request
+// context used by grouped stats.
+func TestImpressionContext(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	repo.EXPECT().GetEligibleCreatives(mock.Anything, mock.Anything).Return([]port.CreativeCandidate{{
+		Creative: domain.Creative{ID: 1},
+		Campaign: domain.Campaign{ID: 1, CPMBid: 1000},
+	}}, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.MatchedBy(func(imp domain.Impression) bool {
+			return imp.Language == "ru" && imp.Geo == "RU" && imp.Placement == domain.PlacementPreRoll
+		}), mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	_, err := NewAdUseCase(repo).RequestAd(context.Background(), domain.UserContext{
+		Language:  "RU",
+		Geo:       "ru",
+		Placement: domain.PlacementPreRoll,
+	})
+	if err != nil {
+		t.Fatalf("RequestAd error: %v", err)
+	}
+}
+
+// TestGroupedStats ensures the derived rates of grouped stats.
+func TestGroupedStats(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	req := port.GroupedStatsReq{GroupBy: port.DimensionPlacement}
+	repo.EXPECT().GetGroupedStats(mock.Anything, req).Return([]port.StatsRow{
+		{Value: domain.PlacementPreRoll, Impressions: 2000, Clicks: 40, Cost: 5000},
+		{Value: "", Impressions: 0, Clicks: 2, Cost: 30},
+	}, nil).Once()
+	svc := NewAdUseCase(repo)
+
+	rows, err := svc.GetGroupedStats(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetGroupedStats error: %v", err)
+	}
+	if r := rows[0]; r.CTR != 0.02 || r.ECPM != 2500 || r.ECPC != 125 {
+		t.Fatalf("unexpected rates: %+v", r)
+	}
+	if r := rows[1]; r.CTR != 0 || r.ECPM != 0 || r.ECPC != 15 {
+		t.Fatalf("unexpected rates without impressions: %+v", r)
+	}
+
+	_, err = svc.GetGroupedStats(context.Background(), port.GroupedStatsReq{GroupBy: "device"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+// TestReservationExpiry ensures selected ads are reserved for the configured TTL.
+func TestReservationExpiry(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPMBid: 1000}},
+		}, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(1), now.Add(time.Minute)).
+		Return(nil)
+
+	svc := NewAdUseCase(repo, WithReservationTTL(time.Minute))
+	svc.now = func() time.Time { return now }
+
+	if resp, err := svc.RequestAd(context.Background(), domain.UserContext{}); err != nil || resp == nil {
+		t.Fatalf("expected ad, got %+v (%v)", resp, err)
+	}
+}
+
+// TestFrequencyRecordedOnReservation ensures a reserved impression counts
+// towards frequency caps before the player confirms it.
+func TestFrequencyRecordedOnReservation(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	capper := mocks.NewMockFrequencyCapper(t)
+	svc := NewAdUseCase(repo, WithFrequencyCapper(capper))
+
+	user := domain.UserContext{UserID: "u1"}
+	candidates := []port.CreativeCandidate{
+		{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 3, CPMBid: 1000}},
+	}
+	repo.EXPECT().GetEligibleCreatives(mock.Anything, user).Return(candidates, nil)
+	capper.EXPECT().Filter(mock.Anything, "u1", mock.Anything).Return(candidates, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(1), mock.Anything).
+		Return(nil)
+	capper.EXPECT().
+		Record(mock.Anything, mock.MatchedBy(func(imp domain.Impression) bool {
+			return imp.UserID == "u1" && imp.CampaignID == 3 && imp.CreativeID == 2
+		})).
+		Once()
+
+	if resp, err := svc.RequestAd(context.Background(), user); err != nil || resp == nil {
+		t.Fatalf("expected ad, got %+v (%v)", resp, err)
+	}
+}
+
+// TestConfirmImpression ensures the beacon bills a reservation once and rejects unknown tokens.
+func TestConfirmImpression(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	imp := &domain.Impression{ID: 5, Token: "t1", CreativeID: 2, CampaignID: 3, UserID: "u1"}
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(imp, nil).Once()
+	if err := svc.ConfirmImpression(context.Background(), "t1"); err != nil {
+		t.Fatalf("ConfirmImpression error: %v", err)
+	}
+
+	// a repeated beacon finds the billed impression and charges nothing
+	repo.EXPECT().CommitImpression(mock.Anything, "t1").Return(nil, nil).Once()
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "t1").Return(imp, nil).Once()
+	if err := svc.ConfirmImpression(context.Background(), "t1"); err != nil {
+		t.Fatalf("repeated ConfirmImpression error: %v", err)
+	}
+
+	repo.EXPECT().CommitImpression(mock.Anything, "expired").Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "expired").Return(nil, nil)
+	if err := svc.ConfirmImpression(context.Background(), "expired"); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// TestSettleImpression ensures an exchange win notice bills the reservation
+// at the exchange price once.
+func TestSettleImpression(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	imp := &domain.Impression{ID: 5, Token: "t1", CreativeID: 2, CampaignID: 3, Cost: 1}
+	repo.EXPECT().SettleImpression(mock.Anything, "t1", int64(812)).Return(imp, nil).Once()
+	if err := svc.SettleImpression(context.Background(), "t1", 812); err != nil {
+		t.Fatalf("SettleImpression error: %v", err)
+	}
+
+	// the billing notice after the win notice charges nothing
+	repo.EXPECT().SettleImpression(mock.Anything, "t1", int64(812)).Return(nil, nil).Once()
+	repo.EXPECT().ResettleImpression(mock.Anything, "t1", int64(812)).Return(nil, nil).Once()
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "t1").Return(imp, nil).Once()
+	if err := svc.SettleImpression(context.Background(), "t1", 812); err != nil {
+		t.Fatalf("repeated SettleImpression error: %v", err)
+	}
+
+	repo.EXPECT().SettleImpression(mock.Anything, "expired", int64(812)).Return(nil, nil)
+	repo.EXPECT().ResettleImpression(mock.Anything, "expired", int64(812)).Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, "expired").Return(nil, nil)
+	if err := svc.SettleImpression(context.Background(), "expired", 812); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// TestSettleAfterClick ensures a win notice arriving after a click billed
+// the impression at the reserved cost bills it down to the exchange price.
+func TestSettleAfterClick(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	repo.EXPECT().SettleImpression(mock.Anything, "t1", int64(812)).Return(nil, nil).Once()
+	repo.EXPECT().ResettleImpression(mock.Anything, "t1", int64(812)).
+		Return(&domain.Impression{ID: 5, Token: "t1", Cost: 1}, nil).Once()
+	if err := svc.SettleImpression(context.Background(), "t1", 812); err != nil {
+		t.Fatalf("SettleImpression error: %v", err)
+	}
+}
+
+// TestNoticeTokens ensures notices accept only notice tokens and the ad
+// tokens of the markup are rejected before any storage lookup.
+func TestNoticeTokens(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	signer := mocks.NewMockTokenSigner(t)
+	svc := NewAdUseCase(repo, WithTokenSigner(signer))
+
+	claims := domain.TokenClaims{ServingID: "s1", CampaignID: 3, CreativeID: 2}
+	notice := claims
+	notice.Purpose = domain.TokenPurposeNotice
+	signer.EXPECT().Verify("ad").Return(claims, nil)
+	signer.EXPECT().Verify("notice").Return(notice, nil)
+	signer.EXPECT().Sign(notice).Return("notice").Once()
+
+	got, err := svc.NoticeToken("ad")
+	if err != nil || got != "notice" {
+		t.Fatalf("NoticeToken = %q, %v", got, err)
+	}
+	if _, err = svc.NoticeToken("notice"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("NoticeToken of a notice token: expected invalid token, got %v", err)
+	}
+
+	if err = svc.SettleImpression(context.Background(), "ad", 0); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("SettleImpression with an ad token: expected invalid token, got %v", err)
+	}
+	if err = svc.ReleaseImpression(context.Background(), "ad"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("ReleaseImpression with an ad token: expected invalid token, got %v", err)
+	}
+	if err = svc.ConfirmImpression(context.Background(), "notice"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("ConfirmImpression with a notice token: expected invalid token, got %v", err)
+	}
+
+	repo.EXPECT().ReleaseImpression(mock.Anything, "s1").Return(true, nil).Once()
+	if err = svc.ReleaseImpression(context.Background(), "notice"); err != nil {
+		t.Fatalf("ReleaseImpression error: %v", err)
+	}
+}
+
+// TestReleaseImpression ensures a lost bid releases its reservation and
+// billed impressions are kept.
+func TestReleaseImpression(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	svc := NewAdUseCase(repo)
+
+	repo.EXPECT().ReleaseImpression(mock.Anything, "t1").Return(true, nil)
+	if err := svc.ReleaseImpression(context.Background(), "t1"); err != nil {
+		t.Fatalf("ReleaseImpression error: %v", err)
+	}
+
+	repo.EXPECT().ReleaseImpression(mock.Anything, "billed").Return(false, nil)
+	if err := svc.ReleaseImpression(context.Background(), "billed"); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// TestSignedTokens ensures ad URLs carry signed tokens and forged ones never reach the repository.
+func TestSignedTokens(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+	signer := mocks.NewMockTokenSigner(t)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 3, CPMBid: 1000}},
+		}, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(1), mock.Anything).
+		Return(nil)
+	signer.EXPECT().
+		Sign(mock.MatchedBy(func(c domain.TokenClaims) bool {
+			return c.ServingID != "" && c.CampaignID == 3 && c.CreativeID == 2 && c.IssuedAt.Equal(now)
+		})).
+		Return("signed")
+
+	svc := NewAdUseCase(repo, WithTokenSigner(signer))
+	svc.now = func() time.Time { return now }
+
+	resp, err := svc.RequestAd(context.Background(), domain.UserContext{})
+	if err != nil || resp == nil {
+		t.Fatalf("expected ad, got %+v (%v)", resp, err)
+	}
+	if resp.ClickURL != "/api/v1/ad/click/signed" || resp.ImpressionURL != "/api/v1/ad/impression/signed" ||
+		resp.TrackingURLs[domain.EventStart] != "/api/v1/ad/track/signed/start" {
+		t.Fatalf("expected signed URLs, got %+v", resp)
+	}
+
+	signer.EXPECT().Verify("forged").Return(domain.TokenClaims{}, domain.ErrInvalidToken)
+	if _, err = svc.RegisterClick(context.Background(), "forged"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Fatalf("expected invalid token, got %v", err)
+	}
+
+	signer.EXPECT().Verify("signed").Return(domain.TokenClaims{ServingID: resp.ServingID}, nil)
+	repo.EXPECT().CommitImpression(mock.Anything, resp.ServingID).Return(nil, nil)
+	repo.EXPECT().FindImpressionByToken(mock.Anything, resp.ServingID).Return(nil, nil)
+	if err = svc.TrackEvent(context.Background(), "signed", domain.EventStart); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// TestAuctionFloor ensures a request floor above the placement reserve raises the clearing price.
+func TestAuctionFloor(t *testing.T) {
+	repo := mocks.NewMockAdRepository(t)
+
+	repo.EXPECT().
+		GetEligibleCreatives(mock.Anything, mock.Anything).
+		Return([]port.CreativeCandidate{
+			{Creative: domain.Creative{ID: 1}, Campaign: domain.Campaign{ID: 1, CPMBid: 3000}},
+			{Creative: domain.Creative{ID: 2}, Campaign: domain.Campaign{ID: 2, CPMBid: 2000}},
+		}, nil)
+	repo.EXPECT().
+		ReserveImpression(mock.Anything, mock.AnythingOfType("domain.Impression"), int64(2501), mock.Anything).
+		Return(nil)
+
+	svc := NewAdUseCase(repo, WithReservePrices(domain.ReservePrices{Default: 1000}))
+
+	resp, err := svc.RequestAd(context.Background(), domain.UserContext{Floor: 2500})
+	if err != nil || resp == nil || resp.CreativeID != 1 || resp.Price != 2501 {
+		t.Fatalf("expected creative 1 at 2501, got %+v (%v)", resp, err)
+	}
+}
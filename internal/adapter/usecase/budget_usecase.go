@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// BudgetUseCase implements port.BudgetUseCase. Days are computed in loc so
+// budgets are restored at local midnight of the advertiser's timezone.
+type BudgetUseCase struct {
+	repo port.BudgetRepository
+	loc  *time.Location
+}
+
+// NewBudgetUseCase creates a new budget maintenance usecase operating in
+// the given timezone.
+func NewBudgetUseCase(repo port.BudgetRepository, loc *time.Location) *BudgetUseCase {
+	return &BudgetUseCase{repo: repo, loc: loc}
+}
+
+// ResetDailyBudgets restores daily budgets once per calendar day.
+func (u *BudgetUseCase) ResetDailyBudgets(ctx context.Context, now time.Time) (*domain.BudgetReset, error) {
+	local := now.In(u.loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	return u.repo.ResetDailyBudgets(ctx, day, u.loc.String())
+}
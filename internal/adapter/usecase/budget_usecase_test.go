@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestResetDailyBudgetsTimezone ensures the reset day is computed in the configured timezone.
+func TestResetDailyBudgetsTimezone(t *testing.T) {
+	repo := mocks.NewMockBudgetRepository(t)
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	svc := NewBudgetUseCase(repo, loc)
+
+	// 22:30 UTC on Jan 1st is already Jan 2nd in UTC+3
+	now := time.Date(2025, 1, 1, 22, 30, 0, 0, time.UTC)
+	day := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().ResetDailyBudgets(mock.Anything, day, "UTC+3").Return(nil, nil)
+
+	if _, err := svc.ResetDailyBudgets(context.Background(), now); err != nil {
+		t.Fatalf("ResetDailyBudgets error: %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// CampaignUseCase implements port.CampaignUseCase on top of a
+// CampaignRepository.
+type CampaignUseCase struct {
+	repo port.CampaignRepository
+}
+
+// NewCampaignUseCase creates a new campaign management usecase.
+func NewCampaignUseCase(repo port.CampaignRepository) *CampaignUseCase {
+	return &CampaignUseCase{repo: repo}
+}
+
+// CreateCampaign validates and stores a new draft campaign with full
+// remaining budgets.
+func (u *CampaignUseCase) CreateCampaign(ctx context.Context, c domain.Campaign) (*domain.Campaign, error) {
+	c.ID = 0
+	c.RemainingDailyBudget = c.DailyBudget
+	c.RemainingTotalBudget = c.TotalBudget
+	c.Status = domain.CampaignStatusDraft
+	if c.PacingMode == "" {
+		c.PacingMode = domain.PacingEven
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return u.repo.CreateCampaign(ctx, c)
+}
+
+// GetCampaign returns a campaign by id or port.ErrNotFound.
+func (u *CampaignUseCase) GetCampaign(ctx context.Context, id int64) (*domain.Campaign, error) {
+	c, err := u.repo.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, port.ErrNotFound
+	}
+	return c, nil
+}
+
+// ListCampaigns returns a page of campaigns.
+func (u *CampaignUseCase) ListCampaigns(ctx context.Context, req port.ListCampaignsReq) ([]domain.Campaign, error) {
+	if req.Status != "" && !req.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domain.ErrValidation, req.Status)
+	}
+	req.Page = req.Page.Normalize()
+	return u.repo.ListCampaigns(ctx, req)
+}
+
+// UpdateCampaign replaces the editable fields of a campaign. Budget changes
+// are applied as deltas to the remaining budgets so spend already made
+// today or over the campaign lifetime is preserved.
+func (u *CampaignUseCase) UpdateCampaign(ctx context.Context, in domain.Campaign) (*domain.Campaign, error) {
+	// the status is left untouched, so no transition is recorded for the actor
+	return u.update(ctx, in.ID, "", func(c *domain.Campaign) error {
+		if c.Status == domain.CampaignStatusArchived {
+			return fmt.Errorf("%w: archived campaigns cannot be edited", domain.ErrInvalidTransition)
+		}
+		c.RemainingDailyBudget = max(0, c.RemainingDailyBudget+in.DailyBudget-c.DailyBudget)
+		c.RemainingTotalBudget = max(0, c.RemainingTotalBudget+in.TotalBudget-c.TotalBudget)
+		c.Name = in.Name
+		c.StartDate = in.StartDate
+		c.EndDate = in.EndDate
+		c.DailyBudget = in.DailyBudget
+		c.TotalBudget = in.TotalBudget
+		c.CPMBid = in.CPMBid
+		c.CPCBid = in.CPCBid
+		c.FrequencyCaps = in.FrequencyCaps
+		if in.PacingMode != "" {
+			c.PacingMode = in.PacingMode
+		}
+		return c.Validate()
+	})
+}
+
+// ChangeStatus moves a campaign through the lifecycle state machine.
+func (u *CampaignUseCase) ChangeStatus(
+	ctx context.Context,
+	id int64,
+	to domain.CampaignStatus,
+	actor string,
+) (*domain.Campaign, error) {
+	if !to.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domain.ErrValidation, to)
+	}
+	return u.update(ctx, id, actor, func(c *domain.Campaign) error {
+		return c.TransitionTo(to, time.Now())
+	})
+}
+
+// ListTransitions returns the status history of a campaign.
+func (u *CampaignUseCase) ListTransitions(ctx context.Context, id int64) ([]domain.StatusTransition, error) {
+	if _, err := u.GetCampaign(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.ListStatusTransitions(ctx, id)
+}
+
+// ApplyAutomaticTransitions moves campaigns that ended or ran out of total
+// budget. Each candidate is re-evaluated under the row lock, so campaigns
+// changed concurrently (e.g. topped up) are left alone.
+func (u *CampaignUseCase) ApplyAutomaticTransitions(ctx context.Context, now time.Time) (int, error) {
+	candidates, err := u.repo.ListAutoTransitionCandidates(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, candidate := range candidates {
+		_, err = u.repo.UpdateCampaign(ctx, candidate.ID, domain.ActorSystem, func(c *domain.Campaign) error {
+			to, ok := c.AutomaticTransition(now)
+			if !ok {
+				return errNoTransition
+			}
+			return c.TransitionTo(to, now)
+		})
+		switch {
+		case errors.Is(err, errNoTransition):
+		case err != nil:
+			return moved, err
+		default:
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+// errNoTransition aborts an automatic update whose campaign no longer needs
+// a status change.
+var errNoTransition = errors.New("no transition required")
+
+// update runs fn inside a locked repository update and maps a missing
+// campaign to port.ErrNotFound.
+func (u *CampaignUseCase) update(
+	ctx context.Context,
+	id int64,
+	actor string,
+	fn func(c *domain.Campaign) error,
+) (*domain.Campaign, error) {
+	c, err := u.repo.UpdateCampaign(ctx, id, actor, fn)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, port.ErrNotFound
+	}
+	return c, nil
+}
+
+// GetTargeting returns the stored targeting or ErrNotFound when none is
+// stored.
+func (u *CampaignUseCase) GetTargeting(ctx context.Context, campaignID int64) (*domain.Targeting, error) {
+	if _, err := u.GetCampaign(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	t, err := u.repo.GetTargeting(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, port.ErrNotFound
+	}
+	t.Normalize()
+	return t, nil
+}
+
+// SetTargeting normalises, validates and stores the targeting of a
+// campaign.
+func (u *CampaignUseCase) SetTargeting(
+	ctx context.Context,
+	campaignID int64,
+	t domain.Targeting,
+) (*domain.Targeting, error) {
+	t.Normalize()
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := u.GetCampaign(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	if err := u.repo.SetTargeting(ctx, campaignID, t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
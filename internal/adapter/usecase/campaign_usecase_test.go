@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestCreateCampaignValidation ensures invalid campaigns never reach the repository.
+func TestCreateCampaignValidation(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	now := time.Now()
+	_, err := svc.CreateCampaign(context.Background(), domain.Campaign{
+		Name:        "c",
+		StartDate:   now,
+		EndDate:     now.Add(-time.Hour),
+		DailyBudget: 100,
+		TotalBudget: 1000,
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+// TestCreateCampaignDefaults ensures new campaigns start as drafts with full budgets.
+func TestCreateCampaignDefaults(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	now := time.Now()
+	repo.EXPECT().
+		CreateCampaign(mock.Anything, mock.AnythingOfType("domain.Campaign")).
+		RunAndReturn(func(_ context.Context, c domain.Campaign) (*domain.Campaign, error) {
+			c.ID = 7
+			return &c, nil
+		})
+
+	c, err := svc.CreateCampaign(context.Background(), domain.Campaign{
+		Name:                 "c",
+		StartDate:            now,
+		EndDate:              now.Add(time.Hour),
+		DailyBudget:          100,
+		TotalBudget:          1000,
+		RemainingDailyBudget: 5,
+		Status:               domain.CampaignStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("CreateCampaign error: %v", err)
+	}
+	if c.Status != domain.CampaignStatusDraft {
+		t.Fatalf("expected draft campaign, got %q", c.Status)
+	}
+	if c.RemainingDailyBudget != 100 || c.RemainingTotalBudget != 1000 {
+		t.Fatalf("unexpected remaining budgets: %d/%d", c.RemainingDailyBudget, c.RemainingTotalBudget)
+	}
+}
+
+// TestUpdateCampaignBudgetDelta ensures budget changes shift remaining budgets.
+func TestUpdateCampaignBudgetDelta(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	now := time.Now()
+	stored := domain.Campaign{
+		ID:                   1,
+		Name:                 "c",
+		StartDate:            now,
+		EndDate:              now.Add(time.Hour),
+		DailyBudget:          100,
+		TotalBudget:          1000,
+		RemainingDailyBudget: 40,
+		RemainingTotalBudget: 900,
+		Status:               domain.CampaignStatusActive,
+		PacingMode:           domain.PacingEven,
+	}
+	repo.EXPECT().
+		UpdateCampaign(mock.Anything, int64(1), mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ int64, _ string, fn func(c *domain.Campaign) error) (*domain.Campaign, error) {
+			c := stored
+			if err := fn(&c); err != nil {
+				return nil, err
+			}
+			return &c, nil
+		})
+
+	update := stored
+	update.DailyBudget = 50
+	update.TotalBudget = 1500
+	c, err := svc.UpdateCampaign(context.Background(), update)
+	if err != nil {
+		t.Fatalf("UpdateCampaign error: %v", err)
+	}
+	if c.RemainingDailyBudget != 0 || c.RemainingTotalBudget != 1400 {
+		t.Fatalf("unexpected remaining budgets: %d/%d", c.RemainingDailyBudget, c.RemainingTotalBudget)
+	}
+}
+
+// TestResumeArchivedCampaign ensures archived campaigns cannot be resumed.
+func TestResumeArchivedCampaign(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	repo.EXPECT().
+		UpdateCampaign(mock.Anything, int64(1), mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ int64, _ string, fn func(c *domain.Campaign) error) (*domain.Campaign, error) {
+			c := domain.Campaign{ID: 1, Status: domain.CampaignStatusArchived}
+			if err := fn(&c); err != nil {
+				return nil, err
+			}
+			return &c, nil
+		})
+
+	_, err := svc.ChangeStatus(context.Background(), 1, domain.CampaignStatusActive, "ops")
+	if !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Fatalf("expected invalid transition, got %v", err)
+	}
+}
+
+// TestCampaignStateMachine ensures only legal lifecycle transitions are accepted.
+func TestCampaignStateMachine(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		from, to domain.CampaignStatus
+		budget   int64
+		ok       bool
+	}{
+		{domain.CampaignStatusDraft, domain.CampaignStatusPendingReview, 10, true},
+		{domain.CampaignStatusDraft, domain.CampaignStatusActive, 10, false},
+		{domain.CampaignStatusPendingReview, domain.CampaignStatusActive, 10, true},
+		{domain.CampaignStatusActive, domain.CampaignStatusPaused, 10, true},
+		{domain.CampaignStatusPaused, domain.CampaignStatusActive, 10, true},
+		{domain.CampaignStatusBudgetExhausted, domain.CampaignStatusActive, 0, false},
+		{domain.CampaignStatusBudgetExhausted, domain.CampaignStatusActive, 10, true},
+		{domain.CampaignStatusEnded, domain.CampaignStatusActive, 10, false},
+		{domain.CampaignStatusEnded, domain.CampaignStatusArchived, 10, true},
+		{domain.CampaignStatusArchived, domain.CampaignStatusPaused, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			c := domain.Campaign{Status: tt.from, RemainingTotalBudget: tt.budget, EndDate: now.Add(time.Hour)}
+			err := c.TransitionTo(tt.to, now)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, domain.ErrInvalidTransition) {
+				t.Fatalf("expected invalid transition, got %v", err)
+			}
+		})
+	}
+}
+
+// TestApplyAutomaticTransitions ensures the worker ends expired campaigns and marks exhausted ones.
+func TestApplyAutomaticTransitions(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	now := time.Now()
+	stored := map[int64]domain.Campaign{
+		1: {ID: 1, Status: domain.CampaignStatusActive, EndDate: now.Add(-time.Minute), RemainingTotalBudget: 10},
+		2: {ID: 2, Status: domain.CampaignStatusActive, EndDate: now.Add(time.Hour)},
+		// topped up after being listed
+		3: {ID: 3, Status: domain.CampaignStatusActive, EndDate: now.Add(time.Hour), RemainingTotalBudget: 10},
+	}
+	repo.EXPECT().ListAutoTransitionCandidates(mock.Anything, now).
+		Return([]domain.Campaign{stored[1], stored[2], stored[3]}, nil)
+
+	got := map[int64]domain.CampaignStatus{}
+	repo.EXPECT().
+		UpdateCampaign(mock.Anything, mock.Anything, domain.ActorSystem, mock.Anything).
+		RunAndReturn(func(_ context.Context, id int64, _ string, fn func(c *domain.Campaign) error) (*domain.Campaign, error) {
+			c := stored[id]
+			if err := fn(&c); err != nil {
+				return nil, err
+			}
+			got[id] = c.Status
+			return &c, nil
+		})
+
+	moved, err := svc.ApplyAutomaticTransitions(context.Background(), now)
+	if err != nil {
+		t.Fatalf("ApplyAutomaticTransitions error: %v", err)
+	}
+	if moved != 2 || got[1] != domain.CampaignStatusEnded || got[2] != domain.CampaignStatusBudgetExhausted {
+		t.Fatalf("unexpected transitions: moved=%d %v", moved, got)
+	}
+}
+
+// TestSetTargetingValidation ensures invalid targeting documents are rejected before storage.
+func TestSetTargetingValidation(t *testing.T) {
+	tests := map[string]domain.Targeting{
+		"country name":      {Geos: []string{"Russia"}},
+		"unknown language":  {Languages: []string{"xx"}},
+		"unknown placement": {Placements: []string{"overlay"}},
+	}
+	for name, tgt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc := NewCampaignUseCase(mocks.NewMockCampaignRepository(t))
+			if _, err := svc.SetTargeting(context.Background(), 1, tgt); !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestSetTargetingNormalization ensures stored targeting uses canonical codes.
+func TestSetTargetingNormalization(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	want := domain.Targeting{
+		Languages:  []string{"en"},
+		Geos:       []string{"RU"},
+		Categories: []string{},
+		Interests:  []string{"gaming"},
+		Placements: []string{domain.PlacementMidRoll},
+	}
+	repo.EXPECT().GetCampaign(mock.Anything, int64(1)).Return(&domain.Campaign{ID: 1}, nil)
+	repo.EXPECT().SetTargeting(mock.Anything, int64(1), want).Return(nil)
+
+	_, err := svc.SetTargeting(context.Background(), 1, domain.Targeting{
+		Languages:  []string{"EN", "en"},
+		Geos:       []string{" ru"},
+		Interests:  []string{"Gaming"},
+		Placements: []string{"MidRoll"},
+	})
+	if err != nil {
+		t.Fatalf("SetTargeting error: %v", err)
+	}
+}
+
+// TestGetTargetingNotSet ensures a campaign without stored targeting, which
+// is never served, is not reported as matching every request.
+func TestGetTargetingNotSet(t *testing.T) {
+	repo := mocks.NewMockCampaignRepository(t)
+	svc := NewCampaignUseCase(repo)
+
+	repo.EXPECT().GetCampaign(mock.Anything, int64(1)).Return(&domain.Campaign{ID: 1}, nil)
+	repo.EXPECT().GetTargeting(mock.Anything, int64(1)).Return(nil, nil)
+	if _, err := svc.GetTargeting(context.Background(), 1); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// TestParseTargetingUnknownKey ensures unknown targeting keys are rejected.
+func TestParseTargetingUnknownKey(t *testing.T) {
+	_, err := domain.ParseTargeting([]byte(`{"languages":["en"],"devices":["tv"]}`))
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// CreativeUseCase implements port.CreativeUseCase. Campaign existence is
+// checked through the CampaignRepository so creatives of unknown campaigns
+// are reported as not found rather than as foreign key violations.
+type CreativeUseCase struct {
+	creatives port.CreativeRepository
+	campaigns port.CampaignRepository
+}
+
+// NewCreativeUseCase creates a new creative management usecase.
+func NewCreativeUseCase(creatives port.CreativeRepository, campaigns port.CampaignRepository) *CreativeUseCase {
+	return &CreativeUseCase{creatives: creatives, campaigns: campaigns}
+}
+
+// CreateCreative normalises, validates and stores a new creative.
+func (u *CreativeUseCase) CreateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error) {
+	c.ID = 0
+	c.Normalize()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.ensureCampaign(ctx, c.CampaignID); err != nil {
+		return nil, err
+	}
+	return u.creatives.CreateCreative(ctx, c)
+}
+
+// GetCreative returns a creative of the campaign or port.ErrNotFound.
+func (u *CreativeUseCase) GetCreative(ctx context.Context, campaignID, id int64) (*domain.Creative, error) {
+	cr, err := u.creatives.GetCreative(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cr == nil || cr.CampaignID != campaignID {
+		return nil, port.ErrNotFound
+	}
+	return cr, nil
+}
+
+// ListCreatives returns a page of the campaign's creatives.
+func (u *CreativeUseCase) ListCreatives(
+	ctx context.Context,
+	campaignID int64,
+	page port.Page,
+) (*port.CreativeList, error) {
+	if err := u.ensureCampaign(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	return u.creatives.ListCreatives(ctx, campaignID, page.Normalize())
+}
+
+// UpdateCreative normalises, validates and stores the editable fields of a
+// creative.
+func (u *CreativeUseCase) UpdateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error) {
+	c.Normalize()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	cr, err := u.creatives.UpdateCreative(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if cr == nil {
+		return nil, port.ErrNotFound
+	}
+	return cr, nil
+}
+
+// DeleteCreative removes a creative that has never been served.
+func (u *CreativeUseCase) DeleteCreative(ctx context.Context, campaignID, id int64) error {
+	deleted, err := u.creatives.DeleteCreative(ctx, campaignID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return port.ErrNotFound
+	}
+	return nil
+}
+
+// ensureCampaign returns port.ErrNotFound when the campaign does not exist.
+func (u *CreativeUseCase) ensureCampaign(ctx context.Context, id int64) error {
+	c, err := u.campaigns.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return port.ErrNotFound
+	}
+	return nil
+}
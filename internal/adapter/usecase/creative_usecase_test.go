@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestCreateCreativeNormalization ensures creatives are normalised before they are stored.
+func TestCreateCreativeNormalization(t *testing.T) {
+	creatives := mocks.NewMockCreativeRepository(t)
+	campaigns := mocks.NewMockCampaignRepository(t)
+	svc := NewCreativeUseCase(creatives, campaigns)
+
+	campaigns.EXPECT().GetCampaign(mock.Anything, int64(3)).Return(&domain.Campaign{ID: 3}, nil)
+	creatives.EXPECT().
+		CreateCreative(mock.Anything, mock.AnythingOfType("domain.Creative")).
+		RunAndReturn(func(_ context.Context, c domain.Creative) (*domain.Creative, error) {
+			return &c, nil
+		})
+
+	cr, err := svc.CreateCreative(context.Background(), domain.Creative{
+		CampaignID: 3,
+		Title:      " Spring sale ",
+		VideoURL:   "https://cdn.example.com/v.mp4",
+		LandingURL: "http://example.com/",
+		Duration:   15,
+		Language:   " EN",
+		Placement:  "Pre_Roll",
+	})
+	if err != nil {
+		t.Fatalf("CreateCreative error: %v", err)
+	}
+	if cr.Title != "Spring sale" || cr.Language != "en" || cr.Placement != domain.PlacementPreRoll {
+		t.Fatalf("creative not normalised: %+v", cr)
+	}
+}
+
+// TestCreateCreativeValidation ensures malformed creatives are rejected.
+func TestCreateCreativeValidation(t *testing.T) {
+	valid := domain.Creative{
+		CampaignID: 1,
+		Title:      "t",
+		VideoURL:   "https://cdn.example.com/v.mp4",
+		LandingURL: "https://example.com/",
+		Duration:   30,
+	}
+	tests := map[string]func(c *domain.Creative){
+		"relative video URL": func(c *domain.Creative) { c.VideoURL = "/v.mp4" },
+		"ftp landing URL":    func(c *domain.Creative) { c.LandingURL = "ftp://example.com/" },
+		"zero duration":      func(c *domain.Creative) { c.Duration = 0 },
+		"long language":      func(c *domain.Creative) { c.Language = "eng" },
+		"unknown placement":  func(c *domain.Creative) { c.Placement = "overlay" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc := NewCreativeUseCase(mocks.NewMockCreativeRepository(t), mocks.NewMockCampaignRepository(t))
+			c := valid
+			mutate(&c)
+			if _, err := svc.CreateCreative(context.Background(), c); !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestGetCreativeOtherCampaign ensures creatives are only visible within their campaign.
+func TestGetCreativeOtherCampaign(t *testing.T) {
+	creatives := mocks.NewMockCreativeRepository(t)
+	svc := NewCreativeUseCase(creatives, mocks.NewMockCampaignRepository(t))
+
+	creatives.EXPECT().GetCreative(mock.Anything, int64(5)).Return(&domain.Creative{ID: 5, CampaignID: 2}, nil)
+
+	if _, err := svc.GetCreative(context.Background(), 1, 5); !errors.Is(err, port.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
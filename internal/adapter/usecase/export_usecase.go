@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+)
+
+// ExportUseCase implements port.ExportUseCase.
+type ExportUseCase struct {
+	repo port.ExportRepository
+}
+
+// NewExportUseCase creates an export usecase reading events from repo.
+func NewExportUseCase(repo port.ExportRepository) *ExportUseCase {
+	return &ExportUseCase{repo: repo}
+}
+
+// exportCursor is the JSON form of a port.ExportPosition inside a cursor.
+type exportCursor struct {
+	T  time.Time      `json:"t"`
+	K  port.EventKind `json:"k"`
+	ID int64          `json:"id"`
+}
+
+// ExportEvents validates the request and streams the events after its
+// cursor, attaching to each event the cursor that resumes after it.
+func (u *ExportUseCase) ExportEvents(ctx context.Context, req port.ExportReq, fn func(port.ExportedEvent) error) error {
+	if req.From.IsZero() || req.To.IsZero() || !req.To.After(req.From) {
+		return fmt.Errorf("%w: export period must be non-empty", domain.ErrValidation)
+	}
+	q := port.ExportQuery{From: req.From, To: req.To, CampaignID: req.CampaignID}
+	if req.Cursor != "" {
+		after, err := decodeExportCursor(req.Cursor)
+		if err != nil {
+			return err
+		}
+		q.After = &after
+	}
+	return u.repo.StreamEvents(ctx, q, func(e port.ExportedEvent) error {
+		e.Cursor = encodeExportCursor(e.Position())
+		return fn(e)
+	})
+}
+
+// encodeExportCursor makes an opaque URL-safe cursor of a position.
+func encodeExportCursor(p port.ExportPosition) string {
+	data, _ := json.Marshal(exportCursor{T: p.CreatedAt.UTC(), K: p.Kind, ID: p.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeExportCursor(s string) (port.ExportPosition, error) {
+	var c exportCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.T.IsZero() || (c.K != port.EventKindImpression && c.K != port.EventKindClick) {
+		return port.ExportPosition{}, fmt.Errorf("%w: invalid cursor", domain.ErrValidation)
+	}
+	return port.ExportPosition{CreatedAt: c.T, Kind: c.K, ID: c.ID}, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/domain"
+	"mesa-ads/internal/core/port"
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestExportCursorResumes checks that the cursor of an exported event
+// resumes the export right after it.
+func TestExportCursorResumes(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	last := port.ExportedEvent{
+		Kind:      port.EventKindClick,
+		ID:        42,
+		CreatedAt: from.Add(90*time.Minute + 1500*time.Microsecond),
+	}
+
+	repo := mocks.NewMockExportRepository(t)
+	repo.EXPECT().
+		StreamEvents(mock.Anything, port.ExportQuery{From: from, To: to}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ port.ExportQuery, fn func(port.ExportedEvent) error) error {
+			return fn(last)
+		}).
+		Once()
+	svc := NewExportUseCase(repo)
+
+	var cursor string
+	err := svc.ExportEvents(context.Background(), port.ExportReq{From: from, To: to}, func(e port.ExportedEvent) error {
+		cursor = e.Cursor
+		return nil
+	})
+	if err != nil || cursor == "" {
+		t.Fatalf("export error %v, cursor %q", err, cursor)
+	}
+
+	repo.EXPECT().
+		StreamEvents(mock.Anything, mock.MatchedBy(func(q port.ExportQuery) bool {
+			return q.After != nil && q.After.Kind == last.Kind && q.After.ID == last.ID &&
+				q.After.CreatedAt.Equal(last.CreatedAt)
+		}), mock.Anything).
+		Return(nil).
+		Once()
+	err = svc.ExportEvents(context.Background(), port.ExportReq{From: from, To: to, Cursor: cursor},
+		func(port.ExportedEvent) error { return nil })
+	if err != nil {
+		t.Fatalf("resumed export error: %v", err)
+	}
+}
+
+// TestExportValidation ensures an empty period or a malformed cursor is
+// rejected before the repository is queried.
+func TestExportValidation(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	svc := NewExportUseCase(mocks.NewMockExportRepository(t))
+	noop := func(port.ExportedEvent) error { return nil }
+
+	for name, req := range map[string]port.ExportReq{
+		"missing from":    {To: from},
+		"reversed period": {From: from, To: from.Add(-time.Hour)},
+		"garbage cursor":  {From: from, To: from.Add(time.Hour), Cursor: "not a cursor"},
+		"unknown kind":    {From: from, To: from.Add(time.Hour), Cursor: encodeExportCursor(port.ExportPosition{CreatedAt: from, Kind: "view"})},
+	} {
+		if err := svc.ExportEvents(context.Background(), req, noop); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("%s: error = %v, want ErrValidation", name, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mesa-ads/internal/core/port"
+)
+
+// healthCheckTimeout bounds each readiness check, so a hanging dependency
+// fails the probe instead of stalling it.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthUseCase implements port.HealthUseCase over a fixed set of checks.
+// Drain makes it report the service as not ready for good.
+type HealthUseCase struct {
+	checks   []port.HealthChecker
+	draining atomic.Bool
+}
+
+// NewHealthUseCase creates a health usecase running checks on every
+// readiness probe.
+func NewHealthUseCase(checks ...port.HealthChecker) *HealthUseCase {
+	return &HealthUseCase{checks: checks}
+}
+
+// Drain marks the service as shutting down. Readiness fails from then on
+// so that load balancers stop sending new requests while the servers
+// finish the ones in flight.
+func (u *HealthUseCase) Drain() {
+	u.draining.Store(true)
+}
+
+// Readiness runs the checks concurrently, each with its own timeout.
+func (u *HealthUseCase) Readiness(ctx context.Context) port.Readiness {
+	if u.draining.Load() {
+		return port.Readiness{Draining: true}
+	}
+	results := make([]port.CheckResult, len(u.checks))
+	var wg sync.WaitGroup
+	for i, check := range u.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			err := check.Check(checkCtx)
+			results[i] = port.CheckResult{Name: check.Name(), Err: err, Duration: time.Since(start)}
+		}()
+	}
+	wg.Wait()
+	return port.Readiness{Checks: results}
+}
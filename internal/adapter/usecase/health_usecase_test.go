@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestReadinessChecks ensures every check runs with a deadline and results
+// keep the order of registration.
+func TestReadinessChecks(t *testing.T) {
+	db := mocks.NewMockHealthChecker(t)
+	db.EXPECT().Name().Return("database")
+	db.EXPECT().Check(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("check has no deadline")
+		}
+		return nil
+	})
+	migrations := mocks.NewMockHealthChecker(t)
+	migrations.EXPECT().Name().Return("migrations")
+	migrations.EXPECT().Check(mock.Anything).Return(errors.New("schema version 11, want 12"))
+	svc := NewHealthUseCase(db, migrations)
+
+	got := svc.Readiness(context.Background())
+	if got.Ready() {
+		t.Fatalf("expected a failed check to make the service not ready")
+	}
+	if len(got.Checks) != 2 || got.Checks[0].Name != "database" || got.Checks[1].Name != "migrations" {
+		t.Fatalf("unexpected checks: %+v", got.Checks)
+	}
+	if got.Checks[0].Err != nil || got.Checks[1].Err == nil {
+		t.Fatalf("unexpected check errors: %+v", got.Checks)
+	}
+}
+
+// TestReadinessDraining ensures the service stays not ready after Drain
+// without running the checks again.
+func TestReadinessDraining(t *testing.T) {
+	db := mocks.NewMockHealthChecker(t)
+	db.EXPECT().Name().Return("database").Once()
+	db.EXPECT().Check(mock.Anything).Return(nil).Once()
+	svc := NewHealthUseCase(db)
+
+	if !svc.Readiness(context.Background()).Ready() {
+		t.Fatalf("expected the service to be ready before Drain")
+	}
+	svc.Drain()
+	got := svc.Readiness(context.Background())
+	if got.Ready() || !got.Draining || len(got.Checks) != 0 {
+		t.Fatalf("unexpected readiness while draining: %+v", got)
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/port"
+)
+
+// backfillChunk is the number of hours a single backfill step aggregates.
+const backfillChunk = 24 * time.Hour
+
+// RollupUseCase implements port.RollupUseCase. An hour is rolled up once
+// delay has passed after its end, leaving time for events whose
+// transactions were still running when the hour ended.
+type RollupUseCase struct {
+	repo  port.RollupRepository
+	delay time.Duration
+}
+
+// NewRollupUseCase creates a rollup usecase that waits delay after the end
+// of an hour before aggregating it.
+func NewRollupUseCase(repo port.RollupRepository, delay time.Duration) *RollupUseCase {
+	return &RollupUseCase{repo: repo, delay: delay}
+}
+
+// RollUp aggregates the hours that ended at least delay before now.
+func (u *RollupUseCase) RollUp(ctx context.Context, now time.Time) (int64, error) {
+	return u.repo.RollUp(ctx, now.Add(-u.delay).UTC().Truncate(time.Hour))
+}
+
+// Backfill extends the rollups back to from, a day at a time, starting
+// next to the hours already covered.
+func (u *RollupUseCase) Backfill(ctx context.Context, from time.Time) (int64, error) {
+	if from.IsZero() {
+		first, err := u.repo.FirstEventAt(ctx)
+		if err != nil || first.IsZero() {
+			return 0, err
+		}
+		from = first
+	}
+	from = from.UTC().Truncate(time.Hour)
+
+	start, _, err := u.repo.RollupRange(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for start.After(from) {
+		start = start.Add(-backfillChunk)
+		if start.Before(from) {
+			start = from
+		}
+		n, err := u.repo.Backfill(ctx, start)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
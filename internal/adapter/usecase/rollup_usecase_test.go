@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mesa-ads/internal/core/port/mocks"
+)
+
+// TestRollUpDelay ensures only hours that ended a delay ago are rolled up.
+func TestRollUpDelay(t *testing.T) {
+	repo := mocks.NewMockRollupRepository(t)
+	svc := NewRollupUseCase(repo, 5*time.Minute)
+
+	// 12:03 is within the delay after 12:00, so only hours up to 11:00 are final
+	now := time.Date(2025, 6, 1, 12, 3, 0, 0, time.UTC)
+	repo.EXPECT().RollUp(mock.Anything, time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)).Return(1, nil).Once()
+
+	if _, err := svc.RollUp(context.Background(), now); err != nil {
+		t.Fatalf("RollUp error: %v", err)
+	}
+}
+
+// TestBackfillChunks ensures the backfill walks back from the rolled up
+// range a day at a time down to the oldest event.
+func TestBackfillChunks(t *testing.T) {
+	repo := mocks.NewMockRollupRepository(t)
+	svc := NewRollupUseCase(repo, 5*time.Minute)
+
+	rolledFrom := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	repo.EXPECT().FirstEventAt(mock.Anything).Return(time.Date(2025, 5, 31, 22, 40, 0, 0, time.UTC), nil).Once()
+	repo.EXPECT().RollupRange(mock.Anything).Return(rolledFrom, rolledFrom.Add(time.Hour), nil).Once()
+	var calls []time.Time
+	repo.EXPECT().Backfill(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, from time.Time) (int64, error) {
+			calls = append(calls, from)
+			return 1, nil
+		}).
+		Times(3)
+
+	hours, err := svc.Backfill(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatalf("Backfill error: %v", err)
+	}
+	want := []time.Time{
+		rolledFrom.Add(-24 * time.Hour),
+		rolledFrom.Add(-48 * time.Hour),
+		time.Date(2025, 5, 31, 22, 0, 0, 0, time.UTC),
+	}
+	if hours != 3 || len(calls) != len(want) {
+		t.Fatalf("hours = %d, calls = %v", hours, calls)
+	}
+	for i := range want {
+		if !calls[i].Equal(want[i]) {
+			t.Errorf("chunk %d starts at %v, want %v", i, calls[i], want[i])
+		}
+	}
+
+	repo.EXPECT().FirstEventAt(mock.Anything).Return(time.Time{}, nil).Once()
+	if hours, err := svc.Backfill(context.Background(), time.Time{}); hours != 0 || err != nil {
+		t.Fatalf("expected nothing to backfill, got %d, %v", hours, err)
+	}
+}
@@ -21,6 +21,10 @@ type Config struct {
 	// prefixed with HTTP_ will populate this struct.
 	HTTP configs.HTTP `envPrefix:"HTTP_"`
 
+	// GRPC holds configuration for the gRPC server. Environment variables
+	// prefixed with GRPC_ will populate this struct.
+	GRPC configs.GRPC `envPrefix:"GRPC_"`
+
 	// Log configures the structured logger. Environment variables prefixed
 	// with LOG_ will populate this struct.
 	Log configs.Logger `envPrefix:"LOG_"`
@@ -28,6 +32,39 @@ type Config struct {
 	// Psql configures the PostgreSQL connection. Environment variables
 	// prefixed with PSQL_ will populate this struct.
 	Psql configs.Postgres `envPrefix:"PSQL_"`
+
+	// Scheduler configures background jobs such as the daily budget reset.
+	// Environment variables prefixed with SCHEDULER_ will populate this
+	// struct.
+	Scheduler configs.Scheduler `envPrefix:"SCHEDULER_"`
+
+	// Ads tunes ad selection. Environment variables prefixed with ADS_ will
+	// populate this struct.
+	Ads configs.Ads `envPrefix:"ADS_"`
+
+	// Token configures the signing of event tokens in ad URLs. Environment
+	// variables prefixed with TOKEN_ will populate this struct.
+	Token configs.Token `envPrefix:"TOKEN_"`
+
+	// OpenRTB configures the OpenRTB bidder endpoint. Environment variables
+	// prefixed with OPENRTB_ will populate this struct.
+	OpenRTB configs.OpenRTB `envPrefix:"OPENRTB_"`
+
+	// Cache configures the in-memory candidate cache. Environment variables
+	// prefixed with CACHE_ will populate this struct.
+	Cache configs.Cache `envPrefix:"CACHE_"`
+
+	// Export configures the raw event export endpoint. Environment
+	// variables prefixed with EXPORT_ will populate this struct.
+	Export configs.Export `envPrefix:"EXPORT_"`
+
+	// Metrics configures the Prometheus metrics. Environment variables
+	// prefixed with METRICS_ will populate this struct.
+	Metrics configs.Metrics `envPrefix:"METRICS_"`
+
+	// Tracing configures the OpenTelemetry exporter. Environment variables
+	// prefixed with TRACING_ will populate this struct.
+	Tracing configs.Tracing `envPrefix:"TRACING_"`
 }
 
 // Load reads configuration from environment variables into a Config. If
@@ -0,0 +1,26 @@
+package configs
+
+import "time"
+
+// Ads tunes ad selection. PacingTolerance is the share of the daily budget
+// an evenly paced campaign may spend ahead of its ideal spend curve before
+// it is throttled. DefaultFrequencyCaps ("3/1h,10/24h") apply to creatives
+// when neither they nor their campaign define caps; FrequencyStore selects
+// where impression history is counted: "postgres" or "memory". CPC bids
+// are ranked by a CTR estimated from the events of the last CTRWindow,
+// recomputed every CTRRefreshInterval and smoothed towards CTRPrior as if
+// CTRPriorWeight impressions had been observed at that rate. ReservePrices
+// ("pre-roll=500,*=100") are the auction floor eCPMs per placement, "*"
+// being the default. Budget of a selected ad stays reserved for
+// ReservationTTL waiting for the player's impression beacon.
+type Ads struct {
+	PacingTolerance      float64       `env:"PACING_TOLERANCE" envDefault:"0.05"`
+	DefaultFrequencyCaps string        `env:"DEFAULT_FREQUENCY_CAPS" envDefault:"3/1h"`
+	FrequencyStore       string        `env:"FREQUENCY_STORE" envDefault:"postgres"`
+	CTRPrior             float64       `env:"CTR_PRIOR" envDefault:"0.01"`
+	CTRPriorWeight       float64       `env:"CTR_PRIOR_WEIGHT" envDefault:"1000"`
+	CTRWindow            time.Duration `env:"CTR_WINDOW" envDefault:"720h"`
+	CTRRefreshInterval   time.Duration `env:"CTR_REFRESH_INTERVAL" envDefault:"5m"`
+	ReservePrices        string        `env:"RESERVE_PRICES"`
+	ReservationTTL       time.Duration `env:"RESERVATION_TTL" envDefault:"5m"`
+}
@@ -0,0 +1,15 @@
+package configs
+
+import "time"
+
+// Cache configures the in-memory candidate cache. The snapshot is reloaded
+// every RefreshInterval and, when Listen is set, as soon as Postgres
+// notifies about campaign, creative or targeting changes. The remaining
+// budgets of the snapshot are polled every BudgetInterval; zero disables
+// polling.
+type Cache struct {
+	Enabled         bool          `env:"ENABLED" envDefault:"true"`
+	RefreshInterval time.Duration `env:"REFRESH_INTERVAL" envDefault:"30s"`
+	BudgetInterval  time.Duration `env:"BUDGET_INTERVAL" envDefault:"2s"`
+	Listen          bool          `env:"LISTEN" envDefault:"true"`
+}
@@ -0,0 +1,10 @@
+package configs
+
+// Export configures the raw event export. APIKeys is a comma separated list
+// of keys accepted as bearer tokens; the endpoint is disabled without keys.
+// BatchSize is the number of events fetched from the database cursor per
+// round trip.
+type Export struct {
+	APIKeys   []string `env:"API_KEYS"`
+	BatchSize int      `env:"BATCH_SIZE" envDefault:"1000"`
+}
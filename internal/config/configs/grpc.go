@@ -0,0 +1,10 @@
+package configs
+
+// GRPC defines configuration for the gRPC server, which runs next to the
+// HTTP server on its own port.
+type GRPC struct {
+	// Enabled starts the gRPC server. Defaults to true.
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+	// Port is the TCP port the gRPC server will listen on. Defaults to 9090.
+	Port uint16 `env:"PORT" envDefault:"9090"`
+}
@@ -1,5 +1,7 @@
 package configs
 
+import "time"
+
 // HTTP defines configuration for the HTTP server. The Port specifies
 // which port the server will bind to. The host may be configured via
 // the ADDRESS environment variable, but port is sufficient for most use
@@ -7,4 +9,9 @@ package configs
 type HTTP struct {
 	// Port is the TCP port the HTTP server will listen on. Defaults to 8080.
 	Port uint16 `env:"PORT" envDefault:"8080"`
+
+	// DrainDelay is how long /readyz reports the service as draining
+	// before the servers stop accepting connections on shutdown, so load
+	// balancers have time to take the instance out of rotation.
+	DrainDelay time.Duration `env:"DRAIN_DELAY" envDefault:"5s"`
 }
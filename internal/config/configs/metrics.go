@@ -0,0 +1,9 @@
+package configs
+
+// Metrics configures the Prometheus metrics. SpendCampaigns caps the
+// number of campaign_id label values of the spend counter: spend of the
+// campaigns billed after the first SpendCampaigns since the start is
+// counted under campaign_id "other".
+type Metrics struct {
+	SpendCampaigns int `env:"SPEND_CAMPAIGNS" envDefault:"500"`
+}
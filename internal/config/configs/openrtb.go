@@ -0,0 +1,9 @@
+package configs
+
+// OpenRTB configures the OpenRTB 2.6 bidder. Bids are priced in Currency;
+// MinorUnits is the number of budget units per currency unit, 100 when
+// budgets are kept in cents. An empty Currency disables the bidder.
+type OpenRTB struct {
+	Currency   string  `env:"CURRENCY" envDefault:"USD"`
+	MinorUnits float64 `env:"MINOR_UNITS" envDefault:"100"`
+}
@@ -0,0 +1,29 @@
+package configs
+
+import "time"
+
+// Scheduler configures background jobs. Timezone is an IANA zone name that
+// defines day boundaries for daily budgets and pacing. BudgetResetInterval controls
+// how often the reset job checks whether the current day was already
+// reset; the reset itself happens once per day. LifecycleInterval controls
+// how often campaigns are checked for automatic status transitions.
+// ReservationReleaseInterval controls how often budget of unconfirmed
+// impressions is returned to campaigns. RollupInterval controls how often
+// hourly stats rollups are extended; an hour is rolled up RollupDelay after
+// it ended so late events are included.
+type Scheduler struct {
+	// Enabled starts the background jobs. Disable it on instances that
+	// should only serve traffic.
+	Enabled                    bool          `env:"ENABLED" envDefault:"true"`
+	Timezone                   string        `env:"TIMEZONE" envDefault:"UTC"`
+	BudgetResetInterval        time.Duration `env:"BUDGET_RESET_INTERVAL" envDefault:"1m"`
+	LifecycleInterval          time.Duration `env:"LIFECYCLE_INTERVAL" envDefault:"1m"`
+	ReservationReleaseInterval time.Duration `env:"RESERVATION_RELEASE_INTERVAL" envDefault:"1m"`
+	RollupInterval             time.Duration `env:"ROLLUP_INTERVAL" envDefault:"1m"`
+	RollupDelay                time.Duration `env:"ROLLUP_DELAY" envDefault:"5m"`
+}
+
+// Location loads the configured timezone.
+func (c Scheduler) Location() (*time.Location, error) {
+	return time.LoadLocation(c.Timezone)
+}
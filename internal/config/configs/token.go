@@ -0,0 +1,16 @@
+package configs
+
+import "time"
+
+// Token configures the signing of impression, tracking and click tokens.
+// Keys is a comma separated list of "id:secret" HMAC keys, the first of
+// which signs new tokens. Tokens are accepted for TTL after they were
+// issued. To rotate keys, prepend the new key, set RotatedAt to the time of
+// the rollout and keep the old key listed: it is accepted until
+// RotationGrace after RotatedAt.
+type Token struct {
+	Keys          string        `env:"KEYS,required"`
+	TTL           time.Duration `env:"TTL" envDefault:"24h"`
+	RotatedAt     time.Time     `env:"ROTATED_AT"`
+	RotationGrace time.Duration `env:"ROTATION_GRACE" envDefault:"24h"`
+}
@@ -0,0 +1,13 @@
+package configs
+
+// Tracing configures OpenTelemetry tracing. Spans are exported over
+// OTLP/gRPC to Endpoint (host:port); tracing is disabled when it is empty.
+// Insecure turns off TLS towards the collector. SampleRatio is the share of
+// new traces recorded; requests continuing a trace keep the caller's
+// decision.
+type Tracing struct {
+	Endpoint    string  `env:"ENDPOINT"`
+	Insecure    bool    `env:"INSECURE" envDefault:"false"`
+	SampleRatio float64 `env:"SAMPLE_RATIO" envDefault:"1"`
+	ServiceName string  `env:"SERVICE_NAME" envDefault:"mesa-ads"`
+}
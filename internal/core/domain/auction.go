@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// AuctionResult is the outcome of a second-price auction.
+type AuctionResult struct {
+	// Winner is the index of the winning bid.
+	Winner int
+	// Price is the clearing price in eCPM units: the second-highest
+	// eligible bid or the reserve, whichever is higher, plus one unit,
+	// but never more than the winning bid.
+	Price float64
+}
+
+// RunAuction runs a second-price auction over eCPM bids. Bids below
+// reserve do not take part. Ties go to the earliest bid. ok is false when
+// no bid reaches the reserve.
+func RunAuction(bids []float64, reserve int64) (result AuctionResult, ok bool) {
+	var (
+		floor  = float64(reserve)
+		winner = -1
+		second = floor
+	)
+	for i, bid := range bids {
+		if bid < floor {
+			continue
+		}
+		switch {
+		case winner < 0:
+			winner = i
+		case bid > bids[winner]:
+			second = bids[winner]
+			winner = i
+		case bid > second:
+			second = bid
+		}
+	}
+	if winner < 0 {
+		return AuctionResult{}, false
+	}
+	price := min(math.Floor(second)+1, bids[winner])
+	return AuctionResult{Winner: winner, Price: price}, true
+}
+
+// ReservePrices holds the minimum eCPM a bid must reach per placement.
+type ReservePrices struct {
+	// ByPlacement maps normalised placements to their reserve.
+	ByPlacement map[string]int64
+	// Default applies to placements without a reserve of their own and to
+	// requests without a placement.
+	Default int64
+}
+
+// For returns the reserve for a placement.
+func (r ReservePrices) For(placement string) int64 {
+	if p, ok := NormalizePlacement(placement); ok {
+		if v, ok := r.ByPlacement[p]; ok {
+			return v
+		}
+	}
+	return r.Default
+}
+
+// ParseReservePrices parses a comma separated list of "placement=eCPM"
+// reserves such as "preroll=500,midroll=300". The placement "*" sets the
+// default reserve. An empty string yields no reserves.
+func ParseReservePrices(s string) (ReservePrices, error) {
+	r := ReservePrices{ByPlacement: make(map[string]int64)}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		placement, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return ReservePrices{}, fmt.Errorf("%w: reserve price %q must look like preroll=500", ErrValidation, part)
+		}
+		price, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || price < 0 {
+			return ReservePrices{}, fmt.Errorf("%w: invalid reserve price %q", ErrValidation, value)
+		}
+		placement = strings.TrimSpace(placement)
+		if placement == "*" {
+			r.Default = price
+			continue
+		}
+		p, ok := NormalizePlacement(placement)
+		if !ok {
+			return ReservePrices{}, fmt.Errorf("%w: unknown placement %q", ErrValidation, placement)
+		}
+		r.ByPlacement[p] = price
+	}
+	return r, nil
+}
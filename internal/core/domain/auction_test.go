@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRunAuction checks winner selection and second-price clearing.
+func TestRunAuction(t *testing.T) {
+	tests := []struct {
+		name       string
+		bids       []float64
+		reserve    int64
+		wantOK     bool
+		wantWinner int
+		wantPrice  float64
+	}{
+		{name: "no bids", wantOK: false},
+		{name: "sole bidder pays one unit", bids: []float64{1000}, wantOK: true, wantWinner: 0, wantPrice: 1},
+		{name: "second price plus one", bids: []float64{500, 1000, 700}, wantOK: true, wantWinner: 1, wantPrice: 701},
+		{name: "bounded by own bid", bids: []float64{1000, 1000}, wantOK: true, wantWinner: 0, wantPrice: 1000},
+		{name: "fractional second price", bids: []float64{300.4, 800}, wantOK: true, wantWinner: 1, wantPrice: 301},
+		{name: "fractional winner", bids: []float64{300, 300.5}, wantOK: true, wantWinner: 1, wantPrice: 300.5},
+		{name: "ties go to earliest", bids: []float64{900, 900, 100}, wantOK: true, wantWinner: 0, wantPrice: 900},
+		{name: "reserve raises price", bids: []float64{1000, 200}, reserve: 400, wantOK: true, wantWinner: 0, wantPrice: 401},
+		{name: "bids below reserve excluded", bids: []float64{100, 399.9}, reserve: 400, wantOK: false},
+		{name: "bid at reserve wins at reserve", bids: []float64{400}, reserve: 400, wantOK: true, wantWinner: 0, wantPrice: 400},
+		{name: "below-reserve bid does not set price", bids: []float64{350, 1000}, reserve: 400, wantOK: true, wantWinner: 1, wantPrice: 401},
+		{name: "zero bids", bids: []float64{0, 0}, wantOK: true, wantWinner: 0, wantPrice: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RunAuction(tt.bids, tt.reserve)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Winner != tt.wantWinner || got.Price != tt.wantPrice {
+				t.Fatalf("got %+v, want winner %d price %v", got, tt.wantWinner, tt.wantPrice)
+			}
+		})
+	}
+}
+
+// TestParseReservePrices checks the configuration format and placement lookup.
+func TestParseReservePrices(t *testing.T) {
+	r, err := ParseReservePrices("PreRoll=500, mid_roll=300, *=100")
+	if err != nil {
+		t.Fatalf("ParseReservePrices error: %v", err)
+	}
+	for placement, want := range map[string]int64{
+		PlacementPreRoll:  500,
+		PlacementMidRoll:  300,
+		PlacementPostRoll: 100,
+		"":                100,
+	} {
+		if got := r.For(placement); got != want {
+			t.Errorf("For(%q) = %d, want %d", placement, got, want)
+		}
+	}
+
+	if r, err = ParseReservePrices(""); err != nil || r.For(PlacementPreRoll) != 0 {
+		t.Fatalf("expected no reserves, got %+v (%v)", r, err)
+	}
+	for _, s := range []string{"pre-roll", "pre-roll=x", "pre-roll=-1", "banner=10"} {
+		if _, err = ParseReservePrices(s); !errors.Is(err, ErrValidation) {
+			t.Fatalf("%q: expected validation error, got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// BudgetReset records a restoration of campaign daily budgets. Date is the
+// calendar day, in Timezone, the reset was performed for. At most one reset
+// exists per day.
+type BudgetReset struct {
+	Date      time.Time
+	Timezone  string
+	Campaigns int64
+	ResetAt   time.Time
+}
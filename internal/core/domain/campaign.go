@@ -1,6 +1,15 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTransition is returned when a campaign cannot be moved from its
+// current status to the requested one (see CampaignStatus.CanTransitionTo).
+var ErrInvalidTransition = errors.New("invalid campaign status transition")
 
 // Campaign represents an advertising campaign.
 // Budgets are stored in integer units (e.g. cents).
@@ -13,9 +22,52 @@ type Campaign struct {
 	TotalBudget          int64
 	RemainingDailyBudget int64
 	RemainingTotalBudget int64
-	CPMBid               int64  // cost per thousand impressions
-	CPCBid               int64  // cost per click
-	Status               string // active, paused, ended
+	CPMBid               int64 // cost per thousand impressions
+	CPCBid               int64 // cost per click
+	Status               CampaignStatus
+	PacingMode           PacingMode
+	FrequencyCaps        []FrequencyCap // per user, across all creatives
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
+
+// Validate checks the invariants the ad selection and budget deduction rely
+// on: a non-empty name, a start date strictly before the end date,
+// non-negative bids and budgets, a known pacing mode, valid frequency caps
+// and remaining budgets that never exceed their configured limits. The
+// returned error wraps ErrValidation.
+func (c *Campaign) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if c.StartDate.IsZero() || c.EndDate.IsZero() {
+		return fmt.Errorf("%w: start and end dates are required", ErrValidation)
+	}
+	if !c.StartDate.Before(c.EndDate) {
+		return fmt.Errorf("%w: start date must be before end date", ErrValidation)
+	}
+	if c.CPMBid < 0 || c.CPCBid < 0 {
+		return fmt.Errorf("%w: bids must not be negative", ErrValidation)
+	}
+	if !c.PacingMode.Valid() {
+		return fmt.Errorf("%w: unknown pacing mode %q", ErrValidation, c.PacingMode)
+	}
+	if err := ValidateFrequencyCaps(c.FrequencyCaps); err != nil {
+		return err
+	}
+	if c.DailyBudget < 0 || c.TotalBudget < 0 {
+		return fmt.Errorf("%w: budgets must not be negative", ErrValidation)
+	}
+	if c.RemainingDailyBudget < 0 || c.RemainingDailyBudget > c.DailyBudget {
+		return fmt.Errorf("%w: remaining daily budget must be between 0 and daily budget", ErrValidation)
+	}
+	if c.RemainingTotalBudget < 0 || c.RemainingTotalBudget > c.TotalBudget {
+		return fmt.Errorf("%w: remaining total budget must be between 0 and total budget", ErrValidation)
+	}
+	return nil
+}
+
+// Running reports whether now falls within the campaign flight dates.
+func (c *Campaign) Running(now time.Time) bool {
+	return !now.Before(c.StartDate) && !now.After(c.EndDate)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// CampaignStatus is a state of the campaign lifecycle. Only active
+// campaigns take part in ad selection.
+type CampaignStatus string
+
+// Campaign lifecycle states.
+const (
+	CampaignStatusDraft           CampaignStatus = "draft"
+	CampaignStatusPendingReview   CampaignStatus = "pending_review"
+	CampaignStatusActive          CampaignStatus = "active"
+	CampaignStatusPaused          CampaignStatus = "paused"
+	CampaignStatusBudgetExhausted CampaignStatus = "budget_exhausted"
+	CampaignStatusEnded           CampaignStatus = "ended"
+	CampaignStatusArchived        CampaignStatus = "archived"
+)
+
+// campaignTransitions lists the legal target states for every state.
+// Archived is terminal.
+var campaignTransitions = map[CampaignStatus][]CampaignStatus{
+	CampaignStatusDraft:         {CampaignStatusPendingReview, CampaignStatusArchived},
+	CampaignStatusPendingReview: {CampaignStatusActive, CampaignStatusDraft, CampaignStatusArchived},
+	CampaignStatusActive: {
+		CampaignStatusPaused, CampaignStatusBudgetExhausted, CampaignStatusEnded, CampaignStatusArchived,
+	},
+	CampaignStatusPaused:          {CampaignStatusActive, CampaignStatusEnded, CampaignStatusArchived},
+	CampaignStatusBudgetExhausted: {CampaignStatusActive, CampaignStatusEnded, CampaignStatusArchived},
+	CampaignStatusEnded:           {CampaignStatusArchived},
+	CampaignStatusArchived:        nil,
+}
+
+// Valid reports whether s is a known campaign status.
+func (s CampaignStatus) Valid() bool {
+	_, ok := campaignTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether the state machine allows moving from s
+// to the given status.
+func (s CampaignStatus) CanTransitionTo(to CampaignStatus) bool {
+	return slices.Contains(campaignTransitions[s], to)
+}
+
+// StatusTransition records a change of campaign status and who made it.
+// Actor is "system" for automatic transitions.
+type StatusTransition struct {
+	ID         int64
+	CampaignID int64
+	From       CampaignStatus
+	To         CampaignStatus
+	Actor      string
+	CreatedAt  time.Time
+}
+
+// ActorSystem identifies transitions made by background workers.
+const ActorSystem = "system"
+
+// TransitionTo moves the campaign to the given status. Besides the state
+// machine rules, a campaign can only become active while it has remaining
+// total budget and its end date is in the future. The returned error wraps
+// ErrInvalidTransition.
+func (c *Campaign) TransitionTo(to CampaignStatus, now time.Time) error {
+	if !to.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrValidation, to)
+	}
+	if !c.Status.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, c.Status, to)
+	}
+	if to == CampaignStatusActive {
+		if c.RemainingTotalBudget <= 0 {
+			return fmt.Errorf("%w: total budget is exhausted", ErrInvalidTransition)
+		}
+		if !now.Before(c.EndDate) {
+			return fmt.Errorf("%w: campaign end date has passed", ErrInvalidTransition)
+		}
+	}
+	c.Status = to
+	return nil
+}
+
+// AutomaticTransition returns the status the campaign should be moved to by
+// the lifecycle worker, if any: campaigns past their end date end, and
+// active campaigns without remaining total budget become budget_exhausted.
+func (c *Campaign) AutomaticTransition(now time.Time) (CampaignStatus, bool) {
+	switch c.Status {
+	case CampaignStatusActive, CampaignStatusPaused, CampaignStatusBudgetExhausted:
+		if !now.Before(c.EndDate) {
+			return CampaignStatusEnded, true
+		}
+	}
+	if c.Status == CampaignStatusActive && c.RemainingTotalBudget <= 0 {
+		return CampaignStatusBudgetExhausted, true
+	}
+	return "", false
+}
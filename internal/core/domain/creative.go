@@ -1,18 +1,99 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Known creative placements.
+const (
+	PlacementPreRoll  = "pre-roll"
+	PlacementMidRoll  = "mid-roll"
+	PlacementPostRoll = "post-roll"
+)
 
 // Creative represents an individual advertisement video.
 type Creative struct {
-	ID         int64
-	CampaignID int64
-	Title      string
-	VideoURL   string
-	LandingURL string
-	Duration   int // in seconds
-	Language   string
-	Category   string
-	Placement  string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID            int64
+	CampaignID    int64
+	Title         string
+	VideoURL      string
+	LandingURL    string
+	Duration      int // in seconds
+	Language      string
+	Category      string
+	Placement     string
+	FrequencyCaps []FrequencyCap // per user, this creative only
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NormalizePlacement maps common spellings of a placement ("PreRoll",
+// "pre_roll", " Pre-Roll ") to its canonical form. The second result is
+// false when the value is not a known placement.
+func NormalizePlacement(s string) (string, bool) {
+	key := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(s))
+	switch key {
+	case "preroll":
+		return PlacementPreRoll, true
+	case "midroll":
+		return PlacementMidRoll, true
+	case "postroll":
+		return PlacementPostRoll, true
+	}
+	return s, false
+}
+
+// Normalize trims free-form fields and brings language, category and
+// placement values to their canonical case and spelling. Unknown placements
+// are left as is and rejected by Validate.
+func (c *Creative) Normalize() {
+	c.Title = strings.TrimSpace(c.Title)
+	c.VideoURL = strings.TrimSpace(c.VideoURL)
+	c.LandingURL = strings.TrimSpace(c.LandingURL)
+	c.Language = strings.ToLower(strings.TrimSpace(c.Language))
+	c.Category = strings.ToLower(strings.TrimSpace(c.Category))
+	c.Placement = strings.TrimSpace(c.Placement)
+	if p, ok := NormalizePlacement(c.Placement); ok {
+		c.Placement = p
+	}
+}
+
+// Validate checks that a normalised creative can be served: both URLs are
+// absolute http(s) URLs, the duration is positive, the language is an
+// ISO 639-1 code, the placement is known and the frequency caps are valid.
+// Language and placement may be empty. The returned error wraps
+// ErrValidation.
+func (c *Creative) Validate() error {
+	if c.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrValidation)
+	}
+	if !isHTTPURL(c.VideoURL) {
+		return fmt.Errorf("%w: video URL must be an absolute http(s) URL", ErrValidation)
+	}
+	if !isHTTPURL(c.LandingURL) {
+		return fmt.Errorf("%w: landing URL must be an absolute http(s) URL", ErrValidation)
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("%w: duration must be positive", ErrValidation)
+	}
+	if c.Language != "" && !IsLanguageCode(c.Language) {
+		return fmt.Errorf("%w: unknown language code %q", ErrValidation, c.Language)
+	}
+	if _, ok := NormalizePlacement(c.Placement); c.Placement != "" && !ok {
+		return fmt.Errorf("%w: unknown placement %q", ErrValidation, c.Placement)
+	}
+	return ValidateFrequencyCaps(c.FrequencyCaps)
+}
+
+// isHTTPURL reports whether s is an absolute URL with an http or https
+// scheme and a host.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
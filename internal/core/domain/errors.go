@@ -0,0 +1,7 @@
+package domain
+
+import "errors"
+
+// ErrValidation is wrapped by every error describing invalid domain input.
+// Callers use errors.Is to distinguish bad input from internal failures.
+var ErrValidation = errors.New("validation failed")
@@ -12,7 +12,15 @@ type Impression struct {
 	CampaignID int64
 	UserID     string
 	Cost       int64
-	CreatedAt  time.Time
+	// ClickCost is charged when the impression is clicked. It is the
+	// auction clearing price of CPC winners and zero otherwise.
+	ClickCost int64
+	// Language, Geo and Placement are taken from the normalized user
+	// context of the ad request for stats breakdowns.
+	Language  string
+	Geo       string
+	Placement string
+	CreatedAt time.Time
 }
 
 // Click is a record of a click event.
@@ -26,3 +34,11 @@ type Click struct {
 	Cost         int64
 	CreatedAt    time.Time
 }
+
+// EventCounts are the impressions and clicks of a creative over a period.
+type EventCounts struct {
+	CampaignID  int64
+	CreativeID  int64
+	Impressions int64
+	Clicks      int64
+}
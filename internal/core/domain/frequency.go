@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency capping limits.
+const (
+	// MaxFrequencyCaps is the number of caps a campaign or creative may have.
+	MaxFrequencyCaps = 5
+	// MaxFrequencyWindow is the longest supported cap window. Counter stores
+	// only need to keep impressions this long.
+	MaxFrequencyWindow = 30 * 24 * time.Hour
+)
+
+// FrequencyCap allows a user at most Limit impressions within a sliding
+// Window. In JSON the window is a Go duration string such as "1h" or "24h".
+type FrequencyCap struct {
+	Limit  int
+	Window time.Duration
+}
+
+type frequencyCapJSON struct {
+	Limit  int
+	Window string
+}
+
+// MarshalJSON encodes the window as a duration string.
+func (f FrequencyCap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(frequencyCapJSON{Limit: f.Limit, Window: f.Window.String()})
+}
+
+// UnmarshalJSON decodes a window given as a duration string.
+func (f *FrequencyCap) UnmarshalJSON(data []byte) error {
+	var v frequencyCapJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	window, err := time.ParseDuration(v.Window)
+	if err != nil {
+		return fmt.Errorf("%w: invalid frequency cap window %q", ErrValidation, v.Window)
+	}
+	*f = FrequencyCap{Limit: v.Limit, Window: window}
+	return nil
+}
+
+// String formats the cap as "limit/window", e.g. "3/1h0m0s".
+func (f FrequencyCap) String() string {
+	return strconv.Itoa(f.Limit) + "/" + f.Window.String()
+}
+
+// ValidateFrequencyCaps checks that there are at most MaxFrequencyCaps caps,
+// each with a positive limit and a window between one second and
+// MaxFrequencyWindow. The returned error wraps ErrValidation.
+func ValidateFrequencyCaps(caps []FrequencyCap) error {
+	if len(caps) > MaxFrequencyCaps {
+		return fmt.Errorf("%w: at most %d frequency caps are allowed", ErrValidation, MaxFrequencyCaps)
+	}
+	for _, f := range caps {
+		if f.Limit <= 0 {
+			return fmt.Errorf("%w: frequency cap limit must be positive", ErrValidation)
+		}
+		if f.Window < time.Second || f.Window > MaxFrequencyWindow {
+			return fmt.Errorf("%w: frequency cap window must be between 1s and %s", ErrValidation, MaxFrequencyWindow)
+		}
+	}
+	return nil
+}
+
+// ParseFrequencyCaps parses a comma separated list of "limit/window" caps
+// such as "3/1h,10/24h". An empty string yields no caps.
+func ParseFrequencyCaps(s string) ([]FrequencyCap, error) {
+	var caps []FrequencyCap
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		limit, window, ok := strings.Cut(part, "/")
+		if !ok {
+			return nil, fmt.Errorf("%w: frequency cap %q must look like 3/1h", ErrValidation, part)
+		}
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid frequency cap limit %q", ErrValidation, limit)
+		}
+		d, err := time.ParseDuration(window)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid frequency cap window %q", ErrValidation, window)
+		}
+		caps = append(caps, FrequencyCap{Limit: n, Window: d})
+	}
+	if err := ValidateFrequencyCaps(caps); err != nil {
+		return nil, err
+	}
+	return caps, nil
+}
+
+// FrequencyEvent is an impression as seen by frequency capping.
+type FrequencyEvent struct {
+	CampaignID int64
+	CreativeID int64
+	At         time.Time
+}
@@ -0,0 +1,18 @@
+package domain
+
+// PacingMode controls how a campaign spends its daily budget over the day.
+type PacingMode string
+
+// Pacing modes. Even pacing is the default.
+const (
+	// PacingEven spreads the daily budget evenly across the day.
+	PacingEven PacingMode = "even"
+	// PacingASAP serves the campaign as often as possible until its daily
+	// budget is spent.
+	PacingASAP PacingMode = "asap"
+)
+
+// Valid reports whether m is a known pacing mode.
+func (m PacingMode) Valid() bool {
+	return m == PacingEven || m == PacingASAP
+}
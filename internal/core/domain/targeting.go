@@ -1,6 +1,16 @@
 package domain
 
-// Targeting describes who should see a campaign
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Targeting describes who should see a campaign. An empty list matches every
+// value of its dimension; interests match when at least one of them is among
+// the user's interests.
 type Targeting struct {
 	Languages  []string `json:"languages"`
 	Geos       []string `json:"geos"`
@@ -8,3 +18,97 @@ type Targeting struct {
 	Interests  []string `json:"interests"`
 	Placements []string `json:"placements"`
 }
+
+// ParseTargeting strictly decodes a targeting document. Unknown keys and
+// trailing data are rejected. The result is normalised and validated; the
+// returned error wraps ErrValidation.
+func ParseTargeting(data []byte) (Targeting, error) {
+	var t Targeting
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return Targeting{}, fmt.Errorf("%w: %s", ErrValidation, err.Error())
+	}
+	if dec.More() {
+		return Targeting{}, fmt.Errorf("%w: unexpected data after targeting document", ErrValidation)
+	}
+	t.Normalize()
+	if err := t.Validate(); err != nil {
+		return Targeting{}, err
+	}
+	return t, nil
+}
+
+// Normalize lower-cases languages, categories and interests, upper-cases
+// geos, canonicalises placements and removes blanks and duplicates.
+func (t *Targeting) Normalize() {
+	t.Languages = normalizeList(t.Languages, strings.ToLower)
+	t.Geos = normalizeList(t.Geos, strings.ToUpper)
+	t.Categories = normalizeList(t.Categories, strings.ToLower)
+	t.Interests = normalizeList(t.Interests, strings.ToLower)
+	t.Placements = normalizeList(t.Placements, func(s string) string {
+		p, _ := NormalizePlacement(s)
+		return p
+	})
+}
+
+// Validate checks that languages are ISO 639-1 codes, geos are ISO 3166-1
+// alpha-2 codes and placements are known. The returned error wraps
+// ErrValidation.
+func (t *Targeting) Validate() error {
+	for _, l := range t.Languages {
+		if !IsLanguageCode(l) {
+			return fmt.Errorf("%w: unknown language code %q", ErrValidation, l)
+		}
+	}
+	for _, g := range t.Geos {
+		if !IsCountryCode(g) {
+			return fmt.Errorf("%w: unknown country code %q", ErrValidation, g)
+		}
+	}
+	for _, p := range t.Placements {
+		if _, ok := NormalizePlacement(p); !ok {
+			return fmt.Errorf("%w: unknown placement %q", ErrValidation, p)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the user context satisfies the targeting.
+func (t *Targeting) Matches(user UserContext) bool {
+	if len(t.Languages) > 0 && !slices.Contains(t.Languages, user.Language) {
+		return false
+	}
+	if len(t.Geos) > 0 && !slices.Contains(t.Geos, user.Geo) {
+		return false
+	}
+	if len(t.Categories) > 0 && !slices.Contains(t.Categories, user.Category) {
+		return false
+	}
+	if len(t.Placements) > 0 && !slices.Contains(t.Placements, user.Placement) {
+		return false
+	}
+	if len(t.Interests) > 0 {
+		for _, v := range t.Interests {
+			if slices.Contains(user.Interests, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// normalizeList trims and maps every value, dropping blanks and duplicates
+// while keeping the original order. It always returns a non-nil slice so
+// the JSON representation uses [] rather than null.
+func normalizeList(in []string, fn func(string) string) []string {
+	out := make([]string, 0, len(in))
+	for _, v := range in {
+		v = fn(strings.TrimSpace(v))
+		if v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
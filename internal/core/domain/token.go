@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidToken is returned for event tokens that are malformed, carry a
+// wrong signature or have expired.
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenPurpose tells apart tokens of the same impression handed to
+// different parties, so one cannot be used in place of the other.
+type TokenPurpose string
+
+const (
+	// TokenPurposeAd tokens are embedded in the ad markup and presented
+	// back by the player on impression, tracking and click requests.
+	TokenPurposeAd TokenPurpose = ""
+	// TokenPurposeNotice tokens are only given to an exchange in the win,
+	// billing and loss notice URLs of a bid, which set the billed price.
+	TokenPurposeNotice TokenPurpose = "notice"
+)
+
+// TokenClaims are the facts an event token vouches for. The token is
+// issued with the ad and presented back by the player on impression,
+// tracking and click requests, or by an exchange on notices.
+type TokenClaims struct {
+	// ServingID identifies the impression; it is the impression token
+	// stored with reservations, impressions and events.
+	ServingID  string
+	CampaignID int64
+	CreativeID int64
+	IssuedAt   time.Time
+	Purpose    TokenPurpose
+}
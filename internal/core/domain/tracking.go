@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// TrackingEvent is a playback event reported by a video player.
+type TrackingEvent string
+
+// Tracking events reported by VAST players.
+const (
+	EventStart         TrackingEvent = "start"
+	EventFirstQuartile TrackingEvent = "firstQuartile"
+	EventMidpoint      TrackingEvent = "midpoint"
+	EventThirdQuartile TrackingEvent = "thirdQuartile"
+	EventComplete      TrackingEvent = "complete"
+	EventSkip          TrackingEvent = "skip"
+	EventPause         TrackingEvent = "pause"
+	EventMute          TrackingEvent = "mute"
+	EventUnmute        TrackingEvent = "unmute"
+)
+
+// TrackingEvents lists the supported tracking events in playback order.
+var TrackingEvents = []TrackingEvent{
+	EventStart, EventFirstQuartile, EventMidpoint, EventThirdQuartile,
+	EventComplete, EventSkip, EventPause, EventMute, EventUnmute,
+}
+
+// Valid reports whether e is a supported tracking event.
+func (e TrackingEvent) Valid() bool {
+	for _, v := range TrackingEvents {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// PlaybackEvent is a tracking event reported for an impression. Each event
+// type is recorded at most once per impression token.
+type PlaybackEvent struct {
+	ID           int64
+	Token        string
+	ImpressionID int64
+	CreativeID   int64
+	CampaignID   int64
+	Event        TrackingEvent
+	CreatedAt    time.Time
+}
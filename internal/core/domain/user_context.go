@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 // UserContext describes input from an ad request. It captures information
 // about the viewer and the content context such as language, geo, category
 // and interests. The HTTP layer should construct this struct from
@@ -11,4 +13,20 @@ type UserContext struct {
 	Category  string
 	Interests []string
 	Placement string
+	// Floor is the minimum eCPM the caller accepts, such as an exchange
+	// bid floor. The higher of it and the placement reserve applies.
+	Floor int64
+}
+
+// Normalize brings the context to the same canonical form as Targeting so
+// that values can be compared with simple equality.
+func (u *UserContext) Normalize() {
+	u.UserID = strings.TrimSpace(u.UserID)
+	u.Language = strings.ToLower(strings.TrimSpace(u.Language))
+	u.Geo = strings.ToUpper(strings.TrimSpace(u.Geo))
+	u.Category = strings.ToLower(strings.TrimSpace(u.Category))
+	if len(u.Interests) > 0 {
+		u.Interests = normalizeList(u.Interests, strings.ToLower)
+	}
+	u.Placement, _ = NormalizePlacement(strings.TrimSpace(u.Placement))
 }
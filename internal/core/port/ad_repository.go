@@ -3,11 +3,23 @@ package port
 import (
 	"context"
 	"errors"
+	"time"
 
 	"mesa-ads/internal/core/domain"
 )
 
-var ErrInsufficientBudget = errors.New("insufficient budget")
+var (
+	ErrInsufficientBudget = errors.New("insufficient budget")
+	// ErrNotFound is returned by use cases when the requested entity does
+	// not exist. Repositories keep returning nil, nil for missing rows.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when an operation would break referential
+	// integrity, e.g. deleting a creative that already has impressions.
+	ErrConflict = errors.New("conflict")
+	// ErrDuplicate is returned when an event with the same token was
+	// already recorded. Nothing is charged again.
+	ErrDuplicate = errors.New("duplicate event")
+)
 
 // AdRepository defines the persistence layer for the ad engine. It is an
 // outbound port in hexagonal architecture. Implementations must be
@@ -16,14 +28,50 @@ type AdRepository interface {
 	// GetEligibleCreatives returns creatives that match targeting and have
 	// available budget.
 	GetEligibleCreatives(ctx context.Context, user domain.UserContext) ([]CreativeCandidate, error)
-	// CreateImpressionAndDeductBudget stores an impression event and decrements
-	// campaign budget (CPM).
-	CreateImpressionAndDeductBudget(ctx context.Context, imp domain.Impression, cpmBid int64) error
+	// ReserveImpression decrements campaign budget (CPM) by the
+	// impression's share of cpmPrice, the clearing price per thousand
+	// impressions, and holds the impression as a reservation until it is
+	// committed or expiresAt passes.
+	ReserveImpression(ctx context.Context, imp domain.Impression, cpmPrice int64, expiresAt time.Time) error
+	// CommitImpression turns the unexpired reservation of token into a
+	// billed impression and returns it. It returns nil, nil when there is
+	// no such reservation.
+	CommitImpression(ctx context.Context, token string) (*domain.Impression, error)
+	// SettleImpression is CommitImpression at a clearing price set
+	// elsewhere, e.g. by an exchange: the impression is billed its share of
+	// cpmPrice, at most the reserved cost, and the rest of the reservation
+	// returns to the budgets.
+	SettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error)
+	// ResettleImpression lowers the cost of an impression of token that a
+	// click or tracking event committed at the reserved cost before its
+	// exchange notice to its share of cpmPrice, and returns the difference
+	// to the budgets. An impression is settled once: it returns nil, nil
+	// for settled impressions and unknown tokens.
+	ResettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error)
+	// ReleaseImpression drops the reservation of token and returns its
+	// budget to the campaign. It reports false when there is no such
+	// reservation.
+	ReleaseImpression(ctx context.Context, token string) (bool, error)
+	// ReleaseExpiredReservations drops reservations expired at now and
+	// returns their budget to the campaigns. It returns the number of
+	// released reservations.
+	ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error)
 	// CreateClickAndDeductBudget stores a click event and decrements campaign
-	// budget (CPC).
-	CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcBid int64) error
+	// budget (CPC) by cpcPrice. A repeated click of a token results in
+	// ErrDuplicate.
+	CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcPrice int64) error
+	// CreatePlaybackEvent stores a player tracking event. Repeated events
+	// of the same type for a token are ignored.
+	CreatePlaybackEvent(ctx context.Context, event domain.PlaybackEvent) error
 	// GetStats returns aggregated statistics for campaigns in a period.
 	GetStats(ctx context.Context, req StatsReq) (*StatsResp, error)
+	// GetStatsTimeSeries returns the non-empty buckets of the period in
+	// ascending order. req.Location is always set.
+	GetStatsTimeSeries(ctx context.Context, req TimeSeriesReq) ([]StatsBucket, error)
+	// GetGroupedStats returns impressions, clicks and cost per value of
+	// req.GroupBy ordered by cost, highest first. Derived rates are left
+	// zero.
+	GetGroupedStats(ctx context.Context, req GroupedStatsReq) ([]StatsRow, error)
 
 	// FindImpressionByToken finds an impression by its token.
 	FindImpressionByToken(ctx context.Context, token string) (*domain.Impression, error)
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"mesa-ads/internal/core/domain"
@@ -11,42 +12,113 @@ import (
 // interface represents the primary port into the application domain. Mock
 // implementations can be generated from this interface for testing.
 type AdUseCase interface {
-	// RequestAd selects a suitable creative for the provided user context,
-	// records an impression and deducts CPM budget if applicable. It
-	// returns nil when no creative matches the targeting or budgets are
-	// exhausted. An error is returned on internal failures.
+	// RequestAd selects a suitable creative for the provided user context
+	// in a second-price auction and reserves the clearing price from CPM
+	// budgets if applicable. It returns nil when no creative matches the
+	// targeting or budgets are exhausted. An error is returned on internal
+	// failures.
 	RequestAd(ctx context.Context, user domain.UserContext) (*AdResponse, error)
 
+	// ConfirmImpression turns the reservation made by RequestAd into a
+	// billed impression once the player reports the ad as shown. Repeated
+	// confirmations are idempotent. Forged or expired tokens result in
+	// domain.ErrInvalidToken, unknown tokens and expired reservations in
+	// ErrNotFound.
+	ConfirmImpression(ctx context.Context, token string) error
+
+	// NoticeToken returns the token of the exchange notices of the ad
+	// issued with the ad token. Only notice tokens settle and release
+	// impressions, so the tokens in the ad markup cannot set the price.
+	NoticeToken(token string) (string, error)
+
+	// SettleImpression is ConfirmImpression at the clearing price of an
+	// exchange auction, cpmPrice per thousand impressions in budget units,
+	// for a notice token. The impression is never billed more than was
+	// reserved; the difference returns to the budgets, also when a click
+	// or tracking event billed the impression before the notice. Ad tokens
+	// result in domain.ErrInvalidToken.
+	SettleImpression(ctx context.Context, token string, cpmPrice int64) error
+
+	// ReleaseImpression drops the reservation made by RequestAd, returning
+	// its budget, when the ad will not be shown, e.g. after a lost exchange
+	// auction. Forged, expired and ad tokens result in
+	// domain.ErrInvalidToken, tokens without a pending reservation in
+	// ErrNotFound.
+	ReleaseImpression(ctx context.Context, token string) error
+
 	// RegisterClick records a click event by token and deducts CPC budget
-	// when configured. It returns the landing URL for redirection. If the
-	// token is unknown or invalid, an error is returned. Duplicate clicks
+	// when configured. It returns the landing URL for redirection. Forged
+	// or expired tokens result in domain.ErrInvalidToken and are rejected
+	// without a storage lookup; unknown tokens result in an error. Duplicate clicks
 	// are treated idempotently and return the same URL without additional
 	// charges.
 	RegisterClick(ctx context.Context, token string) (string, error)
 
+	// TrackEvent records a player tracking event for the impression
+	// identified by token. Unknown events result in domain.ErrValidation,
+	// forged or expired tokens in domain.ErrInvalidToken and unknown tokens
+	// in ErrNotFound. Repeated events are ignored.
+	TrackEvent(ctx context.Context, token string, event domain.TrackingEvent) error
+
 	// GetStats returns aggregated impressions, clicks and cost for the
 	// specified campaign (optional) and time period. When campaignID is
-	// nil the stats across all campaigns are returned.
+	// nil the stats across all campaigns are returned. The current CTR
+	// estimate of the campaign, or the global one, is included.
 	GetStats(ctx context.Context, req StatsReq) (*StatsResp, error)
+
+	// GetStatsTimeSeries returns impressions, clicks and cost per hour or
+	// day of the period, in the requested time zone. Every bucket of the
+	// period is present; buckets without events are zero. An unknown
+	// granularity or a period that is empty or too long results in
+	// domain.ErrValidation.
+	GetStatsTimeSeries(ctx context.Context, req TimeSeriesReq) ([]StatsBucket, error)
+
+	// GetGroupedStats returns impressions, clicks, cost and the derived
+	// CTR, eCPM and eCPC per value of a dimension, most expensive first.
+	// An unknown dimension results in domain.ErrValidation.
+	GetGroupedStats(ctx context.Context, req GroupedStatsReq) ([]StatsRow, error)
 }
 
 // AdResponse represents the selected ad details returned to the client.
 // It is a DTO used by the HTTP layer and does not contain domain behaviour.
+// ServingID identifies the impression and Token is the signed token
+// embedded in its URLs; URLs are relative to the service root. Price is the
+// auction clearing price in eCPM units.
 type AdResponse struct {
-	CreativeID int64
-	Duration   int
-	VideoURL   string
-	ClickURL   string
+	ServingID     string
+	Token         string
+	CampaignID    int64
+	CreativeID    int64
+	Price         float64
+	Title         string
+	Duration      int
+	VideoURL      string
+	ClickURL      string
+	ImpressionURL string
+	TrackingURLs  map[domain.TrackingEvent]string
 }
 
 // StatsResp contains aggregated event counts and cost for campaigns. It is
 // returned by repository and usecase methods when requesting statistics.
 // Impressions and Clicks count the number of respective events. Cost
-// sums the cost of those events in integer currency units.
+// sums the cost of those events in integer currency units. Events counts
+// player tracking events by type. Reach estimates the number of distinct
+// users who saw an impression. EstimatedCTR is the smoothed CTR the ad
+// selection currently assumes, AvgFrequency is the number of impressions
+// per reached user and the rates are the shares of impressions that reached
+// a quartile or completed; they are filled in by the use case.
 type StatsResp struct {
-	Impressions int64
-	Clicks      int64
-	Cost        int64
+	Impressions       int64
+	Clicks            int64
+	Cost              int64
+	Events            map[domain.TrackingEvent]int64
+	Reach             int64
+	AvgFrequency      float64
+	EstimatedCTR      float64
+	FirstQuartileRate float64
+	MidpointRate      float64
+	ThirdQuartileRate float64
+	CompletionRate    float64
 }
 
 type StatsReq struct {
@@ -54,3 +126,72 @@ type StatsReq struct {
 	To         time.Time
 	CampaignID *int64
 }
+
+// Granularity is the width of a stats time series bucket.
+type Granularity string
+
+const (
+	GranularityHour Granularity = "hour"
+	GranularityDay  Granularity = "day"
+)
+
+// TimeSeriesReq requests stats split into buckets of Granularity. Bucket
+// boundaries follow the wall clock of Location, UTC when nil, so daily
+// buckets start at local midnight.
+type TimeSeriesReq struct {
+	StatsReq
+	Granularity Granularity
+	Location    *time.Location
+}
+
+// StatsBucket holds the events of the bucket starting at Start. Cost sums
+// impression and click cost like StatsResp.
+type StatsBucket struct {
+	Start       time.Time
+	Impressions int64
+	Clicks      int64
+	Cost        int64
+}
+
+// StatsDimension is an attribute of impressions that stats can be grouped
+// by.
+type StatsDimension string
+
+const (
+	DimensionCampaign  StatsDimension = "campaign"
+	DimensionCreative  StatsDimension = "creative"
+	DimensionPlacement StatsDimension = "placement"
+	DimensionLanguage  StatsDimension = "language"
+	DimensionGeo       StatsDimension = "geo"
+)
+
+// StatsDimensions lists the supported dimensions.
+var StatsDimensions = []StatsDimension{
+	DimensionCampaign, DimensionCreative, DimensionPlacement, DimensionLanguage, DimensionGeo,
+}
+
+// Valid reports whether d is a supported dimension.
+func (d StatsDimension) Valid() bool {
+	return slices.Contains(StatsDimensions, d)
+}
+
+// GroupedStatsReq requests stats per value of GroupBy.
+type GroupedStatsReq struct {
+	StatsReq
+	GroupBy StatsDimension
+}
+
+// StatsRow holds the stats of one dimension value. Value is the decimal ID
+// for campaigns and creatives and the request context value otherwise,
+// empty for impressions recorded before the context was stored. CTR is
+// clicks per impression, ECPM the cost per thousand impressions and ECPC
+// the cost per click; they are zero without impressions or clicks.
+type StatsRow struct {
+	Value       string
+	Impressions int64
+	Clicks      int64
+	Cost        int64
+	CTR         float64
+	ECPM        float64
+	ECPC        float64
+}
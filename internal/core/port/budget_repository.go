@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// BudgetRepository defines persistence for scheduled budget maintenance.
+type BudgetRepository interface {
+	// ResetDailyBudgets sets remaining_daily_budget to daily_budget for all
+	// campaigns that are not archived or ended and records the reset for
+	// the given day. It is idempotent per day across concurrent callers:
+	// when the day was already reset it returns nil, nil without touching
+	// the budgets. The first reset ever recorded leaves the budgets as they
+	// are and reports no campaigns.
+	ResetDailyBudgets(ctx context.Context, day time.Time, timezone string) (*domain.BudgetReset, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// BudgetUseCase defines periodic budget maintenance operations. It is
+// driven by the scheduler rather than by user requests.
+type BudgetUseCase interface {
+	// ResetDailyBudgets restores daily budgets for the calendar day that
+	// contains now in the configured timezone. It returns the recorded
+	// reset, or nil when the day was already reset by this or another
+	// instance.
+	ResetDailyBudgets(ctx context.Context, now time.Time) (*domain.BudgetReset, error)
+}
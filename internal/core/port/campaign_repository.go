@@ -0,0 +1,43 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// CampaignRepository defines persistence for campaign management. Like
+// AdRepository it returns nil, nil when a campaign does not exist.
+type CampaignRepository interface {
+	// CreateCampaign stores a new campaign with an empty targeting and
+	// returns it with the generated id and timestamps.
+	CreateCampaign(ctx context.Context, c domain.Campaign) (*domain.Campaign, error)
+	// GetCampaign returns a campaign by id.
+	GetCampaign(ctx context.Context, id int64) (*domain.Campaign, error)
+	// ListCampaigns returns campaigns ordered by id.
+	ListCampaigns(ctx context.Context, req ListCampaignsReq) ([]domain.Campaign, error)
+	// UpdateCampaign locks the campaign row, passes the current state to
+	// update and persists the modified campaign if update returns nil. The
+	// read-modify-write happens in a single transaction so concurrent budget
+	// deductions are not lost. A status change made by update is recorded as
+	// a domain.StatusTransition attributed to actor in the same transaction.
+	UpdateCampaign(
+		ctx context.Context,
+		id int64,
+		actor string,
+		update func(c *domain.Campaign) error,
+	) (*domain.Campaign, error)
+	// ListStatusTransitions returns the status history of a campaign, oldest
+	// first.
+	ListStatusTransitions(ctx context.Context, campaignID int64) ([]domain.StatusTransition, error)
+	// ListAutoTransitionCandidates returns campaigns that may need an
+	// automatic status change at now: serving campaigns past their end date
+	// and active campaigns without remaining total budget.
+	ListAutoTransitionCandidates(ctx context.Context, now time.Time) ([]domain.Campaign, error)
+	// GetTargeting returns the stored targeting of a campaign or nil when
+	// none is stored.
+	GetTargeting(ctx context.Context, campaignID int64) (*domain.Targeting, error)
+	// SetTargeting creates or replaces the targeting of a campaign.
+	SetTargeting(ctx context.Context, campaignID int64, t domain.Targeting) error
+}
@@ -0,0 +1,61 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// CampaignUseCase defines campaign management operations. Every method that
+// changes a campaign validates it with domain.Campaign.Validate so the
+// invariants relied upon by ad selection always hold. Missing campaigns are
+// reported with ErrNotFound.
+type CampaignUseCase interface {
+	// CreateCampaign validates and stores a new campaign. Remaining budgets
+	// start equal to the configured budgets and the campaign starts as a
+	// draft so it does not serve before it is reviewed.
+	CreateCampaign(ctx context.Context, c domain.Campaign) (*domain.Campaign, error)
+
+	// GetCampaign returns a campaign by id.
+	GetCampaign(ctx context.Context, id int64) (*domain.Campaign, error)
+
+	// ListCampaigns returns campaigns, optionally filtered by status.
+	ListCampaigns(ctx context.Context, req ListCampaignsReq) ([]domain.Campaign, error)
+
+	// UpdateCampaign replaces the editable fields (name, dates, budgets,
+	// bids and pacing mode) of the campaign identified by c.ID. An empty
+	// pacing mode keeps the current one. Changing a budget shifts the
+	// matching remaining budget by the same delta, floored at zero.
+	// Archived campaigns cannot be edited.
+	UpdateCampaign(ctx context.Context, c domain.Campaign) (*domain.Campaign, error)
+
+	// ChangeStatus moves a campaign to the given status on behalf of actor.
+	// Transitions not allowed by the lifecycle state machine result in
+	// domain.ErrInvalidTransition.
+	ChangeStatus(ctx context.Context, id int64, to domain.CampaignStatus, actor string) (*domain.Campaign, error)
+
+	// ListTransitions returns the status history of a campaign.
+	ListTransitions(ctx context.Context, id int64) ([]domain.StatusTransition, error)
+
+	// ApplyAutomaticTransitions ends campaigns past their end date and marks
+	// active campaigns without remaining total budget as budget_exhausted.
+	// It returns the number of campaigns moved.
+	ApplyAutomaticTransitions(ctx context.Context, now time.Time) (int, error)
+
+	// GetTargeting returns the targeting of a campaign. New campaigns
+	// start with an empty Targeting that matches every request; campaigns
+	// without stored targeting are not served and give ErrNotFound.
+	GetTargeting(ctx context.Context, campaignID int64) (*domain.Targeting, error)
+
+	// SetTargeting normalises, validates and stores the targeting of a
+	// campaign and returns the stored document.
+	SetTargeting(ctx context.Context, campaignID int64, t domain.Targeting) (*domain.Targeting, error)
+}
+
+// ListCampaignsReq filters and paginates ListCampaigns. An empty Status
+// returns campaigns in every status.
+type ListCampaignsReq struct {
+	Status domain.CampaignStatus
+	Page   Page
+}
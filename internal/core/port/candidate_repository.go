@@ -0,0 +1,26 @@
+package port
+
+import "context"
+
+// CandidateRepository loads the raw material of ad selection independently
+// of any particular request. It lets callers such as an in-memory cache
+// keep their own snapshot of eligible creatives.
+type CandidateRepository interface {
+	// ListActiveCandidates returns every creative of an active, not yet
+	// ended campaign with remaining budget, regardless of the user. The
+	// targeting of each candidate is normalised.
+	ListActiveCandidates(ctx context.Context) ([]CreativeCandidate, error)
+
+	// ListCampaignBudgets returns the remaining budgets of every campaign
+	// ListActiveCandidates would consider, including those that have just
+	// run out of budget.
+	ListCampaignBudgets(ctx context.Context) ([]BudgetUpdate, error)
+}
+
+// BudgetUpdate carries the remaining budgets of a campaign as left by the
+// deductions committed by any instance.
+type BudgetUpdate struct {
+	CampaignID           int64
+	RemainingDailyBudget int64
+	RemainingTotalBudget int64
+}
@@ -0,0 +1,35 @@
+package port
+
+import (
+	"context"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// CreativeRepository defines persistence for creative management. It
+// returns nil, nil when a creative does not exist.
+type CreativeRepository interface {
+	// CreateCreative stores a new creative and returns it with the
+	// generated id and timestamps.
+	CreateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error)
+	// GetCreative returns a creative by id.
+	GetCreative(ctx context.Context, id int64) (*domain.Creative, error)
+	// ListCreatives returns a page of the campaign's creatives ordered by id
+	// together with the total number of creatives in the campaign.
+	ListCreatives(ctx context.Context, campaignID int64, page Page) (*CreativeList, error)
+	// UpdateCreative replaces the editable fields of the creative identified
+	// by c.ID and c.CampaignID.
+	UpdateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error)
+	// DeleteCreative removes a creative of the campaign. It reports false
+	// when the creative does not exist and ErrConflict when impressions
+	// reference it.
+	DeleteCreative(ctx context.Context, campaignID, id int64) (bool, error)
+}
+
+// CreativeList is a page of creatives returned by list operations.
+type CreativeList struct {
+	Items  []domain.Creative
+	Total  int64
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// CreativeUseCase defines creative management operations scoped to a
+// campaign. Creatives are normalised and validated with
+// domain.Creative.Validate before they are stored. Missing campaigns and
+// creatives are reported with ErrNotFound.
+type CreativeUseCase interface {
+	// CreateCreative stores a new creative for c.CampaignID.
+	CreateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error)
+
+	// GetCreative returns a creative of the campaign.
+	GetCreative(ctx context.Context, campaignID, id int64) (*domain.Creative, error)
+
+	// ListCreatives returns a page of the campaign's creatives.
+	ListCreatives(ctx context.Context, campaignID int64, page Page) (*CreativeList, error)
+
+	// UpdateCreative replaces the editable fields of the creative
+	// identified by c.ID within c.CampaignID.
+	UpdateCreative(ctx context.Context, c domain.Creative) (*domain.Creative, error)
+
+	// DeleteCreative removes a creative that has never been served.
+	// Creatives with recorded impressions result in ErrConflict so
+	// historical statistics are preserved.
+	DeleteCreative(ctx context.Context, campaignID, id int64) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// CTRRepository reads the event history click-through rates are estimated
+// from.
+type CTRRepository interface {
+	// ListEventCounts returns per creative impression and click counts of
+	// events made after since. Creatives without impressions are omitted.
+	ListEventCounts(ctx context.Context, since time.Time) ([]domain.EventCounts, error)
+}
+
+// CTREstimator estimates click-through rates used to convert CPC bids into
+// eCPM. Estimates are fractions in [0, 1]. Implementations must be
+// concurrency-safe.
+type CTREstimator interface {
+	// CreativeCTR returns the estimated CTR of a creative of the campaign.
+	CreativeCTR(campaignID, creativeID int64) float64
+	// CampaignCTR returns the estimated CTR of the campaign.
+	CampaignCTR(campaignID int64) float64
+	// GlobalCTR returns the estimated CTR across all campaigns.
+	GlobalCTR() float64
+}
@@ -0,0 +1,76 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// EventKind is the type of an exported event.
+type EventKind string
+
+const (
+	EventKindImpression EventKind = "impression"
+	EventKindClick      EventKind = "click"
+)
+
+// ExportReq selects the impressions and clicks made in [From, To),
+// optionally of one campaign. Cursor, taken from a previously exported
+// event, resumes the export right after that event.
+type ExportReq struct {
+	From       time.Time
+	To         time.Time
+	CampaignID *int64
+	Cursor     string
+}
+
+// ExportQuery is an ExportReq with the cursor decoded. After is nil when
+// the export starts from the beginning of the period.
+type ExportQuery struct {
+	From       time.Time
+	To         time.Time
+	CampaignID *int64
+	After      *ExportPosition
+}
+
+// ExportPosition is the place of an event in the export order: by time,
+// then kind, then id.
+type ExportPosition struct {
+	CreatedAt time.Time
+	Kind      EventKind
+	ID        int64
+}
+
+// ExportedEvent is a raw impression or click. Cursor resumes the export
+// after this event; it is set by the use case.
+type ExportedEvent struct {
+	Cursor     string
+	Kind       EventKind
+	ID         int64
+	Token      string
+	CampaignID int64
+	CreativeID int64
+	UserID     string
+	Cost       int64
+	CreatedAt  time.Time
+}
+
+// Position returns the place of the event in the export order.
+func (e ExportedEvent) Position() ExportPosition {
+	return ExportPosition{CreatedAt: e.CreatedAt, Kind: e.Kind, ID: e.ID}
+}
+
+// ExportRepository streams raw events. Implementations must not buffer the
+// whole result: exports may span months of traffic.
+type ExportRepository interface {
+	// StreamEvents calls fn for each event selected by q in export order
+	// and stops at the first error fn returns.
+	StreamEvents(ctx context.Context, q ExportQuery, fn func(ExportedEvent) error) error
+}
+
+// ExportUseCase exports raw events for reconciliation.
+type ExportUseCase interface {
+	// ExportEvents calls fn for each event selected by req in export order
+	// with its resume cursor set. An invalid period or cursor is reported
+	// as domain.ErrValidation before fn is called.
+	ExportEvents(ctx context.Context, req ExportReq, fn func(ExportedEvent) error) error
+}
@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mesa-ads/internal/core/domain"
+)
+
+// FrequencyStore keeps the per-user impression history frequency caps are
+// evaluated against. Implementations must be concurrency-safe.
+type FrequencyStore interface {
+	// Events returns the user's impressions of the given campaigns made
+	// after since.
+	Events(ctx context.Context, userID string, campaignIDs []int64, since time.Time) ([]domain.FrequencyEvent, error)
+	// Record registers an impression reserved for the user. Stores backed
+	// by an authoritative impression log may ignore it.
+	Record(ctx context.Context, userID string, event domain.FrequencyEvent) error
+}
+
+// FrequencyCapper enforces per-campaign and per-creative frequency caps
+// during ad selection.
+type FrequencyCapper interface {
+	// Filter drops candidates for which the user has reached any cap of
+	// the campaign or of the creative. It may reuse the backing array of
+	// candidates.
+	Filter(ctx context.Context, userID string, candidates []CreativeCandidate) ([]CreativeCandidate, error)
+	// Record registers an impression reserved for the user, so the caps
+	// hold before the player confirms it. Failures are handled by the
+	// implementation since the reservation is already stored.
+	Record(ctx context.Context, imp domain.Impression)
+}
@@ -0,0 +1,52 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// HealthChecker probes a dependency the service needs before it can serve
+// traffic, such as the database or a cache that has to be loaded first.
+type HealthChecker interface {
+	// Name identifies the dependency in readiness reports.
+	Name() string
+	// Check returns an error describing why the dependency is not usable.
+	Check(ctx context.Context) error
+}
+
+// CheckResult is the outcome of one HealthChecker. Err is nil when the
+// dependency is usable.
+type CheckResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Readiness tells whether the service should receive traffic. Checks are
+// not run once the service is draining for shutdown.
+type Readiness struct {
+	Draining bool
+	Checks   []CheckResult
+}
+
+// Ready reports whether the service is not draining and every check
+// passed.
+func (r Readiness) Ready() bool {
+	if r.Draining {
+		return false
+	}
+	for _, c := range r.Checks {
+		if c.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// HealthUseCase reports the readiness of the service for load balancers
+// and orchestrators.
+type HealthUseCase interface {
+	// Readiness runs every check and reports their outcome in the order
+	// the checks were registered.
+	Readiness(ctx context.Context) Readiness
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"mesa-ads/internal/core/domain"
 	"mesa-ads/internal/core/port"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -39,9 +40,66 @@ func (_m *MockAdRepository) EXPECT() *MockAdRepository_Expecter {
 	return &MockAdRepository_Expecter{mock: &_m.Mock}
 }
 
+// CommitImpression provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) CommitImpression(ctx context.Context, token string) (*domain.Impression, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitImpression")
+	}
+
+	var r0 *domain.Impression
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Impression, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Impression); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Impression)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_CommitImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitImpression'
+type MockAdRepository_CommitImpression_Call struct {
+	*mock.Call
+}
+
+// CommitImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockAdRepository_Expecter) CommitImpression(ctx interface{}, token interface{}) *MockAdRepository_CommitImpression_Call {
+	return &MockAdRepository_CommitImpression_Call{Call: _e.mock.On("CommitImpression", ctx, token)}
+}
+
+func (_c *MockAdRepository_CommitImpression_Call) Run(run func(ctx context.Context, token string)) *MockAdRepository_CommitImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_CommitImpression_Call) Return(impression *domain.Impression, err error) *MockAdRepository_CommitImpression_Call {
+	_c.Call.Return(impression, err)
+	return _c
+}
+
+func (_c *MockAdRepository_CommitImpression_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.Impression, error)) *MockAdRepository_CommitImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateClickAndDeductBudget provides a mock function for the type MockAdRepository
-func (_mock *MockAdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcBid int64) error {
-	ret := _mock.Called(ctx, click, cpcBid)
+func (_mock *MockAdRepository) CreateClickAndDeductBudget(ctx context.Context, click domain.Click, cpcPrice int64) error {
+	ret := _mock.Called(ctx, click, cpcPrice)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateClickAndDeductBudget")
@@ -49,7 +107,7 @@ func (_mock *MockAdRepository) CreateClickAndDeductBudget(ctx context.Context, c
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Click, int64) error); ok {
-		r0 = returnFunc(ctx, click, cpcBid)
+		r0 = returnFunc(ctx, click, cpcPrice)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -64,12 +122,12 @@ type MockAdRepository_CreateClickAndDeductBudget_Call struct {
 // CreateClickAndDeductBudget is a helper method to define mock.On call
 //   - ctx
 //   - click
-//   - cpcBid
-func (_e *MockAdRepository_Expecter) CreateClickAndDeductBudget(ctx interface{}, click interface{}, cpcBid interface{}) *MockAdRepository_CreateClickAndDeductBudget_Call {
-	return &MockAdRepository_CreateClickAndDeductBudget_Call{Call: _e.mock.On("CreateClickAndDeductBudget", ctx, click, cpcBid)}
+//   - cpcPrice
+func (_e *MockAdRepository_Expecter) CreateClickAndDeductBudget(ctx interface{}, click interface{}, cpcPrice interface{}) *MockAdRepository_CreateClickAndDeductBudget_Call {
+	return &MockAdRepository_CreateClickAndDeductBudget_Call{Call: _e.mock.On("CreateClickAndDeductBudget", ctx, click, cpcPrice)}
 }
 
-func (_c *MockAdRepository_CreateClickAndDeductBudget_Call) Run(run func(ctx context.Context, click domain.Click, cpcBid int64)) *MockAdRepository_CreateClickAndDeductBudget_Call {
+func (_c *MockAdRepository_CreateClickAndDeductBudget_Call) Run(run func(ctx context.Context, click domain.Click, cpcPrice int64)) *MockAdRepository_CreateClickAndDeductBudget_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(domain.Click), args[2].(int64))
 	})
@@ -81,54 +139,53 @@ func (_c *MockAdRepository_CreateClickAndDeductBudget_Call) Return(err error) *M
 	return _c
 }
 
-func (_c *MockAdRepository_CreateClickAndDeductBudget_Call) RunAndReturn(run func(ctx context.Context, click domain.Click, cpcBid int64) error) *MockAdRepository_CreateClickAndDeductBudget_Call {
+func (_c *MockAdRepository_CreateClickAndDeductBudget_Call) RunAndReturn(run func(ctx context.Context, click domain.Click, cpcPrice int64) error) *MockAdRepository_CreateClickAndDeductBudget_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateImpressionAndDeductBudget provides a mock function for the type MockAdRepository
-func (_mock *MockAdRepository) CreateImpressionAndDeductBudget(ctx context.Context, imp domain.Impression, cpmBid int64) error {
-	ret := _mock.Called(ctx, imp, cpmBid)
+// CreatePlaybackEvent provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) CreatePlaybackEvent(ctx context.Context, event domain.PlaybackEvent) error {
+	ret := _mock.Called(ctx, event)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateImpressionAndDeductBudget")
+		panic("no return value specified for CreatePlaybackEvent")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Impression, int64) error); ok {
-		r0 = returnFunc(ctx, imp, cpmBid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PlaybackEvent) error); ok {
+		r0 = returnFunc(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockAdRepository_CreateImpressionAndDeductBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImpressionAndDeductBudget'
-type MockAdRepository_CreateImpressionAndDeductBudget_Call struct {
+// MockAdRepository_CreatePlaybackEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePlaybackEvent'
+type MockAdRepository_CreatePlaybackEvent_Call struct {
 	*mock.Call
 }
 
-// CreateImpressionAndDeductBudget is a helper method to define mock.On call
+// CreatePlaybackEvent is a helper method to define mock.On call
 //   - ctx
-//   - imp
-//   - cpmBid
-func (_e *MockAdRepository_Expecter) CreateImpressionAndDeductBudget(ctx interface{}, imp interface{}, cpmBid interface{}) *MockAdRepository_CreateImpressionAndDeductBudget_Call {
-	return &MockAdRepository_CreateImpressionAndDeductBudget_Call{Call: _e.mock.On("CreateImpressionAndDeductBudget", ctx, imp, cpmBid)}
+//   - event
+func (_e *MockAdRepository_Expecter) CreatePlaybackEvent(ctx interface{}, event interface{}) *MockAdRepository_CreatePlaybackEvent_Call {
+	return &MockAdRepository_CreatePlaybackEvent_Call{Call: _e.mock.On("CreatePlaybackEvent", ctx, event)}
 }
 
-func (_c *MockAdRepository_CreateImpressionAndDeductBudget_Call) Run(run func(ctx context.Context, imp domain.Impression, cpmBid int64)) *MockAdRepository_CreateImpressionAndDeductBudget_Call {
+func (_c *MockAdRepository_CreatePlaybackEvent_Call) Run(run func(ctx context.Context, event domain.PlaybackEvent)) *MockAdRepository_CreatePlaybackEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.Impression), args[2].(int64))
+		run(args[0].(context.Context), args[1].(domain.PlaybackEvent))
 	})
 	return _c
 }
 
-func (_c *MockAdRepository_CreateImpressionAndDeductBudget_Call) Return(err error) *MockAdRepository_CreateImpressionAndDeductBudget_Call {
+func (_c *MockAdRepository_CreatePlaybackEvent_Call) Return(err error) *MockAdRepository_CreatePlaybackEvent_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAdRepository_CreateImpressionAndDeductBudget_Call) RunAndReturn(run func(ctx context.Context, imp domain.Impression, cpmBid int64) error) *MockAdRepository_CreateImpressionAndDeductBudget_Call {
+func (_c *MockAdRepository_CreatePlaybackEvent_Call) RunAndReturn(run func(ctx context.Context, event domain.PlaybackEvent) error) *MockAdRepository_CreatePlaybackEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -361,6 +418,63 @@ func (_c *MockAdRepository_GetEligibleCreatives_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// GetGroupedStats provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) GetGroupedStats(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupedStats")
+	}
+
+	var r0 []port.StatsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.GroupedStatsReq) ([]port.StatsRow, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.GroupedStatsReq) []port.StatsRow); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.StatsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.GroupedStatsReq) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_GetGroupedStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupedStats'
+type MockAdRepository_GetGroupedStats_Call struct {
+	*mock.Call
+}
+
+// GetGroupedStats is a helper method to define mock.On call
+//   - ctx
+//   - req
+func (_e *MockAdRepository_Expecter) GetGroupedStats(ctx interface{}, req interface{}) *MockAdRepository_GetGroupedStats_Call {
+	return &MockAdRepository_GetGroupedStats_Call{Call: _e.mock.On("GetGroupedStats", ctx, req)}
+}
+
+func (_c *MockAdRepository_GetGroupedStats_Call) Run(run func(ctx context.Context, req port.GroupedStatsReq)) *MockAdRepository_GetGroupedStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.GroupedStatsReq))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_GetGroupedStats_Call) Return(statsRows []port.StatsRow, err error) *MockAdRepository_GetGroupedStats_Call {
+	_c.Call.Return(statsRows, err)
+	return _c
+}
+
+func (_c *MockAdRepository_GetGroupedStats_Call) RunAndReturn(run func(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error)) *MockAdRepository_GetGroupedStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStats provides a mock function for the type MockAdRepository
 func (_mock *MockAdRepository) GetStats(ctx context.Context, req port.StatsReq) (*port.StatsResp, error) {
 	ret := _mock.Called(ctx, req)
@@ -417,3 +531,334 @@ func (_c *MockAdRepository_GetStats_Call) RunAndReturn(run func(ctx context.Cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetStatsTimeSeries provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) GetStatsTimeSeries(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatsTimeSeries")
+	}
+
+	var r0 []port.StatsBucket
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.TimeSeriesReq) ([]port.StatsBucket, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.TimeSeriesReq) []port.StatsBucket); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.StatsBucket)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.TimeSeriesReq) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_GetStatsTimeSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatsTimeSeries'
+type MockAdRepository_GetStatsTimeSeries_Call struct {
+	*mock.Call
+}
+
+// GetStatsTimeSeries is a helper method to define mock.On call
+//   - ctx
+//   - req
+func (_e *MockAdRepository_Expecter) GetStatsTimeSeries(ctx interface{}, req interface{}) *MockAdRepository_GetStatsTimeSeries_Call {
+	return &MockAdRepository_GetStatsTimeSeries_Call{Call: _e.mock.On("GetStatsTimeSeries", ctx, req)}
+}
+
+func (_c *MockAdRepository_GetStatsTimeSeries_Call) Run(run func(ctx context.Context, req port.TimeSeriesReq)) *MockAdRepository_GetStatsTimeSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.TimeSeriesReq))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_GetStatsTimeSeries_Call) Return(statsBuckets []port.StatsBucket, err error) *MockAdRepository_GetStatsTimeSeries_Call {
+	_c.Call.Return(statsBuckets, err)
+	return _c
+}
+
+func (_c *MockAdRepository_GetStatsTimeSeries_Call) RunAndReturn(run func(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error)) *MockAdRepository_GetStatsTimeSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseExpiredReservations provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	ret := _mock.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredReservations")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_ReleaseExpiredReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseExpiredReservations'
+type MockAdRepository_ReleaseExpiredReservations_Call struct {
+	*mock.Call
+}
+
+// ReleaseExpiredReservations is a helper method to define mock.On call
+//   - ctx
+//   - now
+func (_e *MockAdRepository_Expecter) ReleaseExpiredReservations(ctx interface{}, now interface{}) *MockAdRepository_ReleaseExpiredReservations_Call {
+	return &MockAdRepository_ReleaseExpiredReservations_Call{Call: _e.mock.On("ReleaseExpiredReservations", ctx, now)}
+}
+
+func (_c *MockAdRepository_ReleaseExpiredReservations_Call) Run(run func(ctx context.Context, now time.Time)) *MockAdRepository_ReleaseExpiredReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_ReleaseExpiredReservations_Call) Return(n int64, err error) *MockAdRepository_ReleaseExpiredReservations_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAdRepository_ReleaseExpiredReservations_Call) RunAndReturn(run func(ctx context.Context, now time.Time) (int64, error)) *MockAdRepository_ReleaseExpiredReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseImpression provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) ReleaseImpression(ctx context.Context, token string) (bool, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseImpression")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_ReleaseImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseImpression'
+type MockAdRepository_ReleaseImpression_Call struct {
+	*mock.Call
+}
+
+// ReleaseImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockAdRepository_Expecter) ReleaseImpression(ctx interface{}, token interface{}) *MockAdRepository_ReleaseImpression_Call {
+	return &MockAdRepository_ReleaseImpression_Call{Call: _e.mock.On("ReleaseImpression", ctx, token)}
+}
+
+func (_c *MockAdRepository_ReleaseImpression_Call) Run(run func(ctx context.Context, token string)) *MockAdRepository_ReleaseImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_ReleaseImpression_Call) Return(b bool, err error) *MockAdRepository_ReleaseImpression_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockAdRepository_ReleaseImpression_Call) RunAndReturn(run func(ctx context.Context, token string) (bool, error)) *MockAdRepository_ReleaseImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveImpression provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) ReserveImpression(ctx context.Context, imp domain.Impression, cpmPrice int64, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, imp, cpmPrice, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveImpression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Impression, int64, time.Time) error); ok {
+		r0 = returnFunc(ctx, imp, cpmPrice, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdRepository_ReserveImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveImpression'
+type MockAdRepository_ReserveImpression_Call struct {
+	*mock.Call
+}
+
+// ReserveImpression is a helper method to define mock.On call
+//   - ctx
+//   - imp
+//   - cpmPrice
+//   - expiresAt
+func (_e *MockAdRepository_Expecter) ReserveImpression(ctx interface{}, imp interface{}, cpmPrice interface{}, expiresAt interface{}) *MockAdRepository_ReserveImpression_Call {
+	return &MockAdRepository_ReserveImpression_Call{Call: _e.mock.On("ReserveImpression", ctx, imp, cpmPrice, expiresAt)}
+}
+
+func (_c *MockAdRepository_ReserveImpression_Call) Run(run func(ctx context.Context, imp domain.Impression, cpmPrice int64, expiresAt time.Time)) *MockAdRepository_ReserveImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Impression), args[2].(int64), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_ReserveImpression_Call) Return(err error) *MockAdRepository_ReserveImpression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdRepository_ReserveImpression_Call) RunAndReturn(run func(ctx context.Context, imp domain.Impression, cpmPrice int64, expiresAt time.Time) error) *MockAdRepository_ReserveImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResettleImpression provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) ResettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	ret := _mock.Called(ctx, token, cpmPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResettleImpression")
+	}
+
+	var r0 *domain.Impression
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (*domain.Impression, error)); ok {
+		return returnFunc(ctx, token, cpmPrice)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) *domain.Impression); ok {
+		r0 = returnFunc(ctx, token, cpmPrice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Impression)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, token, cpmPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_ResettleImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResettleImpression'
+type MockAdRepository_ResettleImpression_Call struct {
+	*mock.Call
+}
+
+// ResettleImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - cpmPrice
+func (_e *MockAdRepository_Expecter) ResettleImpression(ctx interface{}, token interface{}, cpmPrice interface{}) *MockAdRepository_ResettleImpression_Call {
+	return &MockAdRepository_ResettleImpression_Call{Call: _e.mock.On("ResettleImpression", ctx, token, cpmPrice)}
+}
+
+func (_c *MockAdRepository_ResettleImpression_Call) Run(run func(ctx context.Context, token string, cpmPrice int64)) *MockAdRepository_ResettleImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_ResettleImpression_Call) Return(impression *domain.Impression, err error) *MockAdRepository_ResettleImpression_Call {
+	_c.Call.Return(impression, err)
+	return _c
+}
+
+func (_c *MockAdRepository_ResettleImpression_Call) RunAndReturn(run func(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error)) *MockAdRepository_ResettleImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SettleImpression provides a mock function for the type MockAdRepository
+func (_mock *MockAdRepository) SettleImpression(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error) {
+	ret := _mock.Called(ctx, token, cpmPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleImpression")
+	}
+
+	var r0 *domain.Impression
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (*domain.Impression, error)); ok {
+		return returnFunc(ctx, token, cpmPrice)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) *domain.Impression); ok {
+		r0 = returnFunc(ctx, token, cpmPrice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Impression)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, token, cpmPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdRepository_SettleImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleImpression'
+type MockAdRepository_SettleImpression_Call struct {
+	*mock.Call
+}
+
+// SettleImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - cpmPrice
+func (_e *MockAdRepository_Expecter) SettleImpression(ctx interface{}, token interface{}, cpmPrice interface{}) *MockAdRepository_SettleImpression_Call {
+	return &MockAdRepository_SettleImpression_Call{Call: _e.mock.On("SettleImpression", ctx, token, cpmPrice)}
+}
+
+func (_c *MockAdRepository_SettleImpression_Call) Run(run func(ctx context.Context, token string, cpmPrice int64)) *MockAdRepository_SettleImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAdRepository_SettleImpression_Call) Return(impression *domain.Impression, err error) *MockAdRepository_SettleImpression_Call {
+	_c.Call.Return(impression, err)
+	return _c
+}
+
+func (_c *MockAdRepository_SettleImpression_Call) RunAndReturn(run func(ctx context.Context, token string, cpmPrice int64) (*domain.Impression, error)) *MockAdRepository_SettleImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -39,6 +39,109 @@ func (_m *MockAdUseCase) EXPECT() *MockAdUseCase_Expecter {
 	return &MockAdUseCase_Expecter{mock: &_m.Mock}
 }
 
+// ConfirmImpression provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) ConfirmImpression(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmImpression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdUseCase_ConfirmImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmImpression'
+type MockAdUseCase_ConfirmImpression_Call struct {
+	*mock.Call
+}
+
+// ConfirmImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockAdUseCase_Expecter) ConfirmImpression(ctx interface{}, token interface{}) *MockAdUseCase_ConfirmImpression_Call {
+	return &MockAdUseCase_ConfirmImpression_Call{Call: _e.mock.On("ConfirmImpression", ctx, token)}
+}
+
+func (_c *MockAdUseCase_ConfirmImpression_Call) Run(run func(ctx context.Context, token string)) *MockAdUseCase_ConfirmImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_ConfirmImpression_Call) Return(err error) *MockAdUseCase_ConfirmImpression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdUseCase_ConfirmImpression_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockAdUseCase_ConfirmImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupedStats provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) GetGroupedStats(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupedStats")
+	}
+
+	var r0 []port.StatsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.GroupedStatsReq) ([]port.StatsRow, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.GroupedStatsReq) []port.StatsRow); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.StatsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.GroupedStatsReq) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdUseCase_GetGroupedStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupedStats'
+type MockAdUseCase_GetGroupedStats_Call struct {
+	*mock.Call
+}
+
+// GetGroupedStats is a helper method to define mock.On call
+//   - ctx
+//   - req
+func (_e *MockAdUseCase_Expecter) GetGroupedStats(ctx interface{}, req interface{}) *MockAdUseCase_GetGroupedStats_Call {
+	return &MockAdUseCase_GetGroupedStats_Call{Call: _e.mock.On("GetGroupedStats", ctx, req)}
+}
+
+func (_c *MockAdUseCase_GetGroupedStats_Call) Run(run func(ctx context.Context, req port.GroupedStatsReq)) *MockAdUseCase_GetGroupedStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.GroupedStatsReq))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_GetGroupedStats_Call) Return(statsRows []port.StatsRow, err error) *MockAdUseCase_GetGroupedStats_Call {
+	_c.Call.Return(statsRows, err)
+	return _c
+}
+
+func (_c *MockAdUseCase_GetGroupedStats_Call) RunAndReturn(run func(ctx context.Context, req port.GroupedStatsReq) ([]port.StatsRow, error)) *MockAdUseCase_GetGroupedStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetStats provides a mock function for the type MockAdUseCase
 func (_mock *MockAdUseCase) GetStats(ctx context.Context, req port.StatsReq) (*port.StatsResp, error) {
 	ret := _mock.Called(ctx, req)
@@ -96,6 +199,117 @@ func (_c *MockAdUseCase_GetStats_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// GetStatsTimeSeries provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) GetStatsTimeSeries(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatsTimeSeries")
+	}
+
+	var r0 []port.StatsBucket
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.TimeSeriesReq) ([]port.StatsBucket, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, port.TimeSeriesReq) []port.StatsBucket); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]port.StatsBucket)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, port.TimeSeriesReq) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdUseCase_GetStatsTimeSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatsTimeSeries'
+type MockAdUseCase_GetStatsTimeSeries_Call struct {
+	*mock.Call
+}
+
+// GetStatsTimeSeries is a helper method to define mock.On call
+//   - ctx
+//   - req
+func (_e *MockAdUseCase_Expecter) GetStatsTimeSeries(ctx interface{}, req interface{}) *MockAdUseCase_GetStatsTimeSeries_Call {
+	return &MockAdUseCase_GetStatsTimeSeries_Call{Call: _e.mock.On("GetStatsTimeSeries", ctx, req)}
+}
+
+func (_c *MockAdUseCase_GetStatsTimeSeries_Call) Run(run func(ctx context.Context, req port.TimeSeriesReq)) *MockAdUseCase_GetStatsTimeSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(port.TimeSeriesReq))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_GetStatsTimeSeries_Call) Return(statsBuckets []port.StatsBucket, err error) *MockAdUseCase_GetStatsTimeSeries_Call {
+	_c.Call.Return(statsBuckets, err)
+	return _c
+}
+
+func (_c *MockAdUseCase_GetStatsTimeSeries_Call) RunAndReturn(run func(ctx context.Context, req port.TimeSeriesReq) ([]port.StatsBucket, error)) *MockAdUseCase_GetStatsTimeSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NoticeToken provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) NoticeToken(token string) (string, error) {
+	ret := _mock.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NoticeToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdUseCase_NoticeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NoticeToken'
+type MockAdUseCase_NoticeToken_Call struct {
+	*mock.Call
+}
+
+// NoticeToken is a helper method to define mock.On call
+//   - token
+func (_e *MockAdUseCase_Expecter) NoticeToken(token interface{}) *MockAdUseCase_NoticeToken_Call {
+	return &MockAdUseCase_NoticeToken_Call{Call: _e.mock.On("NoticeToken", token)}
+}
+
+func (_c *MockAdUseCase_NoticeToken_Call) Run(run func(token string)) *MockAdUseCase_NoticeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_NoticeToken_Call) Return(s string, err error) *MockAdUseCase_NoticeToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAdUseCase_NoticeToken_Call) RunAndReturn(run func(token string) (string, error)) *MockAdUseCase_NoticeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RegisterClick provides a mock function for the type MockAdUseCase
 func (_mock *MockAdUseCase) RegisterClick(ctx context.Context, token string) (string, error) {
 	ret := _mock.Called(ctx, token)
@@ -151,6 +365,52 @@ func (_c *MockAdUseCase_RegisterClick_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// ReleaseImpression provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) ReleaseImpression(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseImpression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdUseCase_ReleaseImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseImpression'
+type MockAdUseCase_ReleaseImpression_Call struct {
+	*mock.Call
+}
+
+// ReleaseImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockAdUseCase_Expecter) ReleaseImpression(ctx interface{}, token interface{}) *MockAdUseCase_ReleaseImpression_Call {
+	return &MockAdUseCase_ReleaseImpression_Call{Call: _e.mock.On("ReleaseImpression", ctx, token)}
+}
+
+func (_c *MockAdUseCase_ReleaseImpression_Call) Run(run func(ctx context.Context, token string)) *MockAdUseCase_ReleaseImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_ReleaseImpression_Call) Return(err error) *MockAdUseCase_ReleaseImpression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdUseCase_ReleaseImpression_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockAdUseCase_ReleaseImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RequestAd provides a mock function for the type MockAdUseCase
 func (_mock *MockAdUseCase) RequestAd(ctx context.Context, user domain.UserContext) (*port.AdResponse, error) {
 	ret := _mock.Called(ctx, user)
@@ -207,3 +467,97 @@ func (_c *MockAdUseCase_RequestAd_Call) RunAndReturn(run func(ctx context.Contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// SettleImpression provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) SettleImpression(ctx context.Context, token string, cpmPrice int64) error {
+	ret := _mock.Called(ctx, token, cpmPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleImpression")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, token, cpmPrice)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdUseCase_SettleImpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleImpression'
+type MockAdUseCase_SettleImpression_Call struct {
+	*mock.Call
+}
+
+// SettleImpression is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - cpmPrice
+func (_e *MockAdUseCase_Expecter) SettleImpression(ctx interface{}, token interface{}, cpmPrice interface{}) *MockAdUseCase_SettleImpression_Call {
+	return &MockAdUseCase_SettleImpression_Call{Call: _e.mock.On("SettleImpression", ctx, token, cpmPrice)}
+}
+
+func (_c *MockAdUseCase_SettleImpression_Call) Run(run func(ctx context.Context, token string, cpmPrice int64)) *MockAdUseCase_SettleImpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_SettleImpression_Call) Return(err error) *MockAdUseCase_SettleImpression_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdUseCase_SettleImpression_Call) RunAndReturn(run func(ctx context.Context, token string, cpmPrice int64) error) *MockAdUseCase_SettleImpression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrackEvent provides a mock function for the type MockAdUseCase
+func (_mock *MockAdUseCase) TrackEvent(ctx context.Context, token string, event domain.TrackingEvent) error {
+	ret := _mock.Called(ctx, token, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrackEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TrackingEvent) error); ok {
+		r0 = returnFunc(ctx, token, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdUseCase_TrackEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrackEvent'
+type MockAdUseCase_TrackEvent_Call struct {
+	*mock.Call
+}
+
+// TrackEvent is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - event
+func (_e *MockAdUseCase_Expecter) TrackEvent(ctx interface{}, token interface{}, event interface{}) *MockAdUseCase_TrackEvent_Call {
+	return &MockAdUseCase_TrackEvent_Call{Call: _e.mock.On("TrackEvent", ctx, token, event)}
+}
+
+func (_c *MockAdUseCase_TrackEvent_Call) Run(run func(ctx context.Context, token string, event domain.TrackingEvent)) *MockAdUseCase_TrackEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.TrackingEvent))
+	})
+	return _c
+}
+
+func (_c *MockAdUseCase_TrackEvent_Call) Return(err error) *MockAdUseCase_TrackEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdUseCase_TrackEvent_Call) RunAndReturn(run func(ctx context.Context, token string, event domain.TrackingEvent) error) *MockAdUseCase_TrackEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
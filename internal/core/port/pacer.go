@@ -0,0 +1,12 @@
+package port
+
+import "mesa-ads/internal/core/domain"
+
+// Pacer decides whether a campaign may take part in the current ad
+// selection given how much of its daily budget it has already spent.
+// Implementations must be concurrency-safe.
+type Pacer interface {
+	// Allow reports whether the campaign may compete for the current
+	// request.
+	Allow(c domain.Campaign) bool
+}
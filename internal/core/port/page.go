@@ -0,0 +1,30 @@
+package port
+
+// Page describes offset based pagination for list operations. Use
+// Normalize to apply defaults and bounds before passing it to a repository.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+const (
+	// DefaultPageLimit is used when no limit is requested.
+	DefaultPageLimit = 50
+	// MaxPageLimit caps the number of rows returned by a single request.
+	MaxPageLimit = 500
+)
+
+// Normalize returns a copy of p with the limit clamped to
+// [1, MaxPageLimit] (DefaultPageLimit when unset) and a non-negative offset.
+func (p Page) Normalize() Page {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	return p
+}
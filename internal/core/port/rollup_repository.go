@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// RollupRepository maintains the hourly stats rollups that stats queries
+// read instead of the raw event tables. The rollups cover a contiguous
+// range of whole UTC hours. Implementations serialize concurrent callers.
+type RollupRepository interface {
+	// RollupRange returns the covered hours [from, until).
+	RollupRange(ctx context.Context) (from, until time.Time, err error)
+	// FirstEventAt returns the time of the oldest impression or click, or
+	// the zero time when there are none.
+	FirstEventAt(ctx context.Context) (time.Time, error)
+	// RollUp aggregates the hours from the end of the covered range up to
+	// until and extends the range. It returns the number of hours rolled
+	// up, zero when until is not past the range.
+	RollUp(ctx context.Context, until time.Time) (int64, error)
+	// Backfill aggregates the hours from from up to the start of the
+	// covered range and extends the range back. It returns the number of
+	// hours rolled up, zero when from is not before the range.
+	Backfill(ctx context.Context, from time.Time) (int64, error)
+}
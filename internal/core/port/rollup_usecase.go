@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// RollupUseCase keeps the hourly stats rollups up to date. It is driven by
+// the scheduler and the backfill command rather than by user requests.
+type RollupUseCase interface {
+	// RollUp aggregates the hours that ended long enough before now for
+	// late events to have arrived. It returns the number of hours rolled
+	// up.
+	RollUp(ctx context.Context, now time.Time) (int64, error)
+
+	// Backfill aggregates existing events older than the rollups, from the
+	// hour containing from or, when from is zero, from the oldest event.
+	// It works in chunks so each transaction stays short and an
+	// interrupted backfill can be resumed. It returns the number of hours
+	// rolled up.
+	Backfill(ctx context.Context, from time.Time) (int64, error)
+}
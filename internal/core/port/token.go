@@ -0,0 +1,14 @@
+package port
+
+import "mesa-ads/internal/core/domain"
+
+// TokenSigner issues and verifies the tokens embedded in impression,
+// tracking and click URLs, so forged or stale tokens are rejected before
+// any storage lookup.
+type TokenSigner interface {
+	// Sign returns a URL safe token carrying claims.
+	Sign(claims domain.TokenClaims) string
+	// Verify checks the signature and age of token and returns its claims.
+	// Invalid and expired tokens result in domain.ErrInvalidToken.
+	Verify(token string) (domain.TokenClaims, error)
+}
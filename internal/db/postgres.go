@@ -4,22 +4,34 @@ import (
 	"context"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"mesa-ads/internal/config/configs"
 )
 
+// PoolOption adjusts the pool configuration before the pool is created.
+type PoolOption func(conf *pgxpool.Config)
+
+// WithTracer traces the queries of every pool connection with tracer.
+func WithTracer(tracer pgx.QueryTracer) PoolOption {
+	return func(conf *pgxpool.Config) { conf.ConnConfig.Tracer = tracer }
+}
+
 // NewPostgresPool creates a new pgxpool.Pool with the provided configuration.
 // It sets the maximum and minimum connections according to cfg. The
 // function verifies that a connection can be established by pinging the
 // database with a 5 second timeout. If pinging fails, the pool is closed
 // and an error is returned. The caller must close the returned pool when
 // it is no longer needed.
-func NewPostgresPool(ctx context.Context, cfg configs.Postgres) (*pgxpool.Pool, error) {
+func NewPostgresPool(ctx context.Context, cfg configs.Postgres, opts ...PoolOption) (*pgxpool.Pool, error) {
 	poolConf, err := pgxpool.ParseConfig(cfg.Addr.String())
 	if err != nil {
 		return nil, err
 	}
+	for _, opt := range opts {
+		opt(poolConf)
+	}
 
 	// Use pgx.Logger from context if present; otherwise default is fine.
 	pool, err := pgxpool.NewWithConfig(ctx, poolConf)
@@ -38,7 +38,7 @@ VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,now(),now()) ON CONFLICT DO NOTHING`,
 		// insert targeting
 		targeting := map[string]interface{}{
 			"languages":  []string{"ru", "en"},
-			"geos":       []string{"Armenia", "Russia"},
+			"geos":       []string{"AM", "RU"},
 			"categories": []string{"music", "tech", "sports"},
 			"interests":  []string{"coding", "gaming"},
 			"placements": []string{"pre-roll", "mid-roll"},
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS budget_resets (
+    reset_date DATE PRIMARY KEY,
+    timezone TEXT NOT NULL,
+    campaigns INT NOT NULL,
+    reset_at TIMESTAMP NOT NULL
+);
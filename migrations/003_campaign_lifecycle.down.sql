@@ -0,0 +1,2 @@
+ALTER TABLE campaigns DROP CONSTRAINT IF EXISTS campaigns_status_check;
+DROP TABLE IF EXISTS campaign_status_transitions;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS campaign_status_transitions (
+    id SERIAL PRIMARY KEY,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS campaign_status_transitions_campaign_idx
+    ON campaign_status_transitions (campaign_id, id);
+
+-- statuses written before the lifecycle existed were free-form
+UPDATE campaigns SET status = 'ended' WHERE status NOT IN
+    ('draft', 'pending_review', 'active', 'paused', 'budget_exhausted', 'ended', 'archived');
+
+ALTER TABLE campaigns ADD CONSTRAINT campaigns_status_check CHECK (status IN
+    ('draft', 'pending_review', 'active', 'paused', 'budget_exhausted', 'ended', 'archived'));
@@ -0,0 +1 @@
+ALTER TABLE campaigns DROP COLUMN IF EXISTS pacing_mode;
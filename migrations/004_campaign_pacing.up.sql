@@ -0,0 +1 @@
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS pacing_mode VARCHAR(10) NOT NULL DEFAULT 'even';
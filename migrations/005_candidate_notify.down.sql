@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS campaign_targeting_notify ON campaign_targeting;
+DROP TRIGGER IF EXISTS creatives_notify ON creatives;
+DROP TRIGGER IF EXISTS campaigns_update_notify ON campaigns;
+DROP TRIGGER IF EXISTS campaigns_insert_delete_notify ON campaigns;
+DROP FUNCTION IF EXISTS notify_ad_candidates_changed();
@@ -0,0 +1,30 @@
+-- notifies ad selection caches that campaigns, creatives or targeting changed
+CREATE OR REPLACE FUNCTION notify_ad_candidates_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('ad_candidates_changed', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER campaigns_insert_delete_notify
+    AFTER INSERT OR DELETE ON campaigns
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_ad_candidates_changed();
+
+-- budget deductions touch neither updated_at nor grow the remaining budgets,
+-- so serving ads does not flood the channel; edits, status changes and
+-- daily resets do notify
+CREATE TRIGGER campaigns_update_notify
+    AFTER UPDATE ON campaigns
+    FOR EACH ROW
+    WHEN (OLD.updated_at IS DISTINCT FROM NEW.updated_at
+       OR NEW.remaining_daily_budget > OLD.remaining_daily_budget
+       OR NEW.remaining_total_budget > OLD.remaining_total_budget)
+    EXECUTE FUNCTION notify_ad_candidates_changed();
+
+CREATE TRIGGER creatives_notify
+    AFTER INSERT OR UPDATE OR DELETE ON creatives
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_ad_candidates_changed();
+
+CREATE TRIGGER campaign_targeting_notify
+    AFTER INSERT OR UPDATE OR DELETE ON campaign_targeting
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_ad_candidates_changed();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS impressions_user_created_idx;
+ALTER TABLE creatives DROP COLUMN IF EXISTS frequency_caps;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS frequency_caps;
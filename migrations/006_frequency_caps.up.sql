@@ -0,0 +1,5 @@
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS frequency_caps JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE creatives ADD COLUMN IF NOT EXISTS frequency_caps JSONB NOT NULL DEFAULT '[]';
+
+-- frequency capping reads a user's recent impressions
+CREATE INDEX IF NOT EXISTS impressions_user_created_idx ON impressions (user_id, created_at);
@@ -0,0 +1,6 @@
+ALTER TABLE impressions ADD COLUMN IF NOT EXISTS click_cost BIGINT NOT NULL DEFAULT 0;
+
+-- impressions served before the auction keep paying the campaign CPC bid
+UPDATE impressions i SET click_cost = c.cpc_bid
+FROM campaigns c
+WHERE c.id = i.campaign_id AND c.cpc_bid > 0;
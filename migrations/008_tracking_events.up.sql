@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id SERIAL PRIMARY KEY,
+    token TEXT NOT NULL,
+    impression_id INT NOT NULL REFERENCES impressions(id) ON DELETE CASCADE,
+    creative_id INT NOT NULL REFERENCES creatives(id) ON DELETE CASCADE,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (token, event)
+);
+
+CREATE INDEX IF NOT EXISTS tracking_events_campaign_created_idx ON tracking_events (campaign_id, created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS impression_reservations (
+    id SERIAL PRIMARY KEY,
+    token TEXT UNIQUE NOT NULL,
+    creative_id INT NOT NULL REFERENCES creatives(id) ON DELETE CASCADE,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    cost BIGINT NOT NULL,
+    click_cost BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS impression_reservations_expires_idx ON impression_reservations (expires_at);
@@ -0,0 +1,9 @@
+ALTER TABLE impression_reservations
+    DROP COLUMN IF EXISTS placement,
+    DROP COLUMN IF EXISTS geo,
+    DROP COLUMN IF EXISTS language;
+
+ALTER TABLE impressions
+    DROP COLUMN IF EXISTS placement,
+    DROP COLUMN IF EXISTS geo,
+    DROP COLUMN IF EXISTS language;
@@ -0,0 +1,11 @@
+-- request context of the impression for stats breakdowns; impressions
+-- served before keep empty values
+ALTER TABLE impressions
+    ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS geo TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS placement TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE impression_reservations
+    ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS geo TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS placement TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS tracking_events_created_idx;
+DROP INDEX IF EXISTS clicks_created_idx;
+DROP INDEX IF EXISTS impressions_created_idx;
+DROP TABLE IF EXISTS stats_rollup_state;
+DROP TABLE IF EXISTS stats_hourly_events;
+DROP TABLE IF EXISTS stats_hourly;
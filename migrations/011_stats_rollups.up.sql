@@ -0,0 +1,39 @@
+-- hourly event rollups per campaign and creative; hour is the UTC start
+CREATE TABLE IF NOT EXISTS stats_hourly (
+    hour TIMESTAMP NOT NULL,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    creative_id INT NOT NULL REFERENCES creatives(id) ON DELETE CASCADE,
+    impressions BIGINT NOT NULL,
+    clicks BIGINT NOT NULL,
+    cost BIGINT NOT NULL,
+    PRIMARY KEY (hour, campaign_id, creative_id)
+);
+
+CREATE TABLE IF NOT EXISTS stats_hourly_events (
+    hour TIMESTAMP NOT NULL,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    creative_id INT NOT NULL REFERENCES creatives(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    events BIGINT NOT NULL,
+    PRIMARY KEY (hour, campaign_id, creative_id, event)
+);
+
+CREATE INDEX IF NOT EXISTS stats_hourly_campaign_idx ON stats_hourly (campaign_id, hour);
+CREATE INDEX IF NOT EXISTS stats_hourly_events_campaign_idx ON stats_hourly_events (campaign_id, hour);
+
+-- the rollups cover the hours [rolled_from, rolled_until); the rollup job
+-- moves rolled_until forward, the backfill moves rolled_from back
+CREATE TABLE IF NOT EXISTS stats_rollup_state (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    rolled_from TIMESTAMP NOT NULL,
+    rolled_until TIMESTAMP NOT NULL
+);
+
+INSERT INTO stats_rollup_state (rolled_from, rolled_until)
+VALUES (date_trunc('hour', now() AT TIME ZONE 'UTC'), date_trunc('hour', now() AT TIME ZONE 'UTC'))
+ON CONFLICT DO NOTHING;
+
+-- hourly rollups and the raw edge queries scan by time
+CREATE INDEX IF NOT EXISTS impressions_created_idx ON impressions (created_at);
+CREATE INDEX IF NOT EXISTS clicks_created_idx ON clicks (created_at);
+CREATE INDEX IF NOT EXISTS tracking_events_created_idx ON tracking_events (created_at);
@@ -0,0 +1,14 @@
+-- HyperLogLog sketches of the users who saw an impression, per campaign
+-- and UTC hour
+CREATE TABLE IF NOT EXISTS stats_hourly_reach (
+    hour TIMESTAMP NOT NULL,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    sketch BYTEA NOT NULL,
+    PRIMARY KEY (hour, campaign_id)
+);
+
+CREATE INDEX IF NOT EXISTS stats_hourly_reach_campaign_idx ON stats_hourly_reach (campaign_id, hour);
+
+-- the hours rolled up so far have no sketches: stats read them from the raw
+-- tables again until backfill-stats rolls them up
+UPDATE stats_rollup_state SET rolled_from = rolled_until;
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS campaigns_budget_spent_notify ON campaigns;
+DROP FUNCTION IF EXISTS notify_campaign_budget_spent();
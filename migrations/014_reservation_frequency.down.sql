@@ -0,0 +1 @@
+DROP INDEX IF EXISTS impression_reservations_user_created_idx;
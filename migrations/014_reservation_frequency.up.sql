@@ -0,0 +1,3 @@
+-- frequency capping counts a user's pending reservations too
+CREATE INDEX IF NOT EXISTS impression_reservations_user_created_idx
+    ON impression_reservations (user_id, created_at);
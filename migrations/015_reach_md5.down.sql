@@ -0,0 +1,3 @@
+-- the sketches of the new hash cannot be merged with the old ones either
+DELETE FROM stats_hourly_reach;
+UPDATE stats_rollup_state SET rolled_from = rolled_until;
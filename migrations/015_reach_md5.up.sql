@@ -0,0 +1,6 @@
+-- reach sketches hash user ids with MD5 so that the database can compute
+-- their registers; sketches of the old hash cannot be merged with them.
+-- Stats read the hours rolled up so far from the raw tables again until
+-- backfill-stats rolls them up
+DELETE FROM stats_hourly_reach;
+UPDATE stats_rollup_state SET rolled_from = rolled_until;
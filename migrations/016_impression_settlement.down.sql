@@ -0,0 +1 @@
+ALTER TABLE impressions DROP COLUMN IF EXISTS settled;
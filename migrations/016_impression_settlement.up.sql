@@ -0,0 +1,3 @@
+-- impressions billed at an exchange price; an impression committed by a
+-- click or tracking event before its win notice is settled by the notice
+ALTER TABLE impressions ADD COLUMN IF NOT EXISTS settled BOOLEAN NOT NULL DEFAULT false;
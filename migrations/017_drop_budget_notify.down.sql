@@ -0,0 +1,18 @@
+-- notifies ad selection caches of every budget deduction, so pacing sees the
+-- live spend; the payload is "campaign_id remaining_daily remaining_total"
+CREATE OR REPLACE FUNCTION notify_campaign_budget_spent() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('campaign_budget_spent',
+        NEW.id || ' ' || NEW.remaining_daily_budget || ' ' || NEW.remaining_total_budget);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- growing budgets already reload the whole snapshot through
+-- campaigns_update_notify
+CREATE TRIGGER campaigns_budget_spent_notify
+    AFTER UPDATE OF remaining_daily_budget, remaining_total_budget ON campaigns
+    FOR EACH ROW
+    WHEN (NEW.remaining_daily_budget < OLD.remaining_daily_budget
+       OR NEW.remaining_total_budget < OLD.remaining_total_budget)
+    EXECUTE FUNCTION notify_campaign_budget_spent();
@@ -0,0 +1,5 @@
+-- NOTIFY serializes committing transactions, so notifying every budget
+-- deduction throttled the serving path; ad selection caches poll the
+-- remaining budgets instead
+DROP TRIGGER IF EXISTS campaigns_budget_spent_notify ON campaigns;
+DROP FUNCTION IF EXISTS notify_campaign_budget_spent();
@@ -9,4 +9,7 @@ import "embed"
 //go:embed *.sql
 var FS embed.FS
 
-const Version = 1
+// Version is the number of the latest migration in FS, the schema version
+// the binary expects. Bump it with every new migration: readiness fails on
+// older schemas.
+const Version = 17
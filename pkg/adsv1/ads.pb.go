@@ -0,0 +1,689 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: ads/v1/ads.proto
+
+package adsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RequestAdRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Language  string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Geo       string                 `protobuf:"bytes,3,opt,name=geo,proto3" json:"geo,omitempty"`
+	Category  string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Interests []string               `protobuf:"bytes,5,rep,name=interests,proto3" json:"interests,omitempty"`
+	Placement string                 `protobuf:"bytes,6,opt,name=placement,proto3" json:"placement,omitempty"`
+	// Minimum eCPM in currency minor units.
+	Floor         int64 `protobuf:"varint,7,opt,name=floor,proto3" json:"floor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAdRequest) Reset() {
+	*x = RequestAdRequest{}
+	mi := &file_ads_v1_ads_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAdRequest) ProtoMessage() {}
+
+func (x *RequestAdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAdRequest.ProtoReflect.Descriptor instead.
+func (*RequestAdRequest) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestAdRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RequestAdRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *RequestAdRequest) GetGeo() string {
+	if x != nil {
+		return x.Geo
+	}
+	return ""
+}
+
+func (x *RequestAdRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *RequestAdRequest) GetInterests() []string {
+	if x != nil {
+		return x.Interests
+	}
+	return nil
+}
+
+func (x *RequestAdRequest) GetPlacement() string {
+	if x != nil {
+		return x.Placement
+	}
+	return ""
+}
+
+func (x *RequestAdRequest) GetFloor() int64 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+type Ad struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ServingId  string                 `protobuf:"bytes,1,opt,name=serving_id,json=servingId,proto3" json:"serving_id,omitempty"`
+	CampaignId int64                  `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	CreativeId int64                  `protobuf:"varint,3,opt,name=creative_id,json=creativeId,proto3" json:"creative_id,omitempty"`
+	Title      string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Duration   int32                  `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	VideoUrl   string                 `protobuf:"bytes,6,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	// URLs are relative to the HTTP service root.
+	ClickUrl      string            `protobuf:"bytes,7,opt,name=click_url,json=clickUrl,proto3" json:"click_url,omitempty"`
+	ImpressionUrl string            `protobuf:"bytes,8,opt,name=impression_url,json=impressionUrl,proto3" json:"impression_url,omitempty"`
+	TrackingUrls  map[string]string `protobuf:"bytes,9,rep,name=tracking_urls,json=trackingUrls,proto3" json:"tracking_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Clearing price in eCPM units.
+	Price         float64 `protobuf:"fixed64,10,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ad) Reset() {
+	*x = Ad{}
+	mi := &file_ads_v1_ads_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ad) ProtoMessage() {}
+
+func (x *Ad) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ad.ProtoReflect.Descriptor instead.
+func (*Ad) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Ad) GetServingId() string {
+	if x != nil {
+		return x.ServingId
+	}
+	return ""
+}
+
+func (x *Ad) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *Ad) GetCreativeId() int64 {
+	if x != nil {
+		return x.CreativeId
+	}
+	return 0
+}
+
+func (x *Ad) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Ad) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Ad) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *Ad) GetClickUrl() string {
+	if x != nil {
+		return x.ClickUrl
+	}
+	return ""
+}
+
+func (x *Ad) GetImpressionUrl() string {
+	if x != nil {
+		return x.ImpressionUrl
+	}
+	return ""
+}
+
+func (x *Ad) GetTrackingUrls() map[string]string {
+	if x != nil {
+		return x.TrackingUrls
+	}
+	return nil
+}
+
+func (x *Ad) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type RequestAdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ad            *Ad                    `protobuf:"bytes,1,opt,name=ad,proto3" json:"ad,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAdResponse) Reset() {
+	*x = RequestAdResponse{}
+	mi := &file_ads_v1_ads_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAdResponse) ProtoMessage() {}
+
+func (x *RequestAdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAdResponse.ProtoReflect.Descriptor instead.
+func (*RequestAdResponse) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequestAdResponse) GetAd() *Ad {
+	if x != nil {
+		return x.Ad
+	}
+	return nil
+}
+
+type RegisterClickRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterClickRequest) Reset() {
+	*x = RegisterClickRequest{}
+	mi := &file_ads_v1_ads_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterClickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterClickRequest) ProtoMessage() {}
+
+func (x *RegisterClickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterClickRequest.ProtoReflect.Descriptor instead.
+func (*RegisterClickRequest) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterClickRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RegisterClickResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LandingUrl    string                 `protobuf:"bytes,1,opt,name=landing_url,json=landingUrl,proto3" json:"landing_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterClickResponse) Reset() {
+	*x = RegisterClickResponse{}
+	mi := &file_ads_v1_ads_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterClickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterClickResponse) ProtoMessage() {}
+
+func (x *RegisterClickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterClickResponse.ProtoReflect.Descriptor instead.
+func (*RegisterClickResponse) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegisterClickResponse) GetLandingUrl() string {
+	if x != nil {
+		return x.LandingUrl
+	}
+	return ""
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 24 hours before the request.
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Defaults to the time of the request.
+	To *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Stats across all campaigns when unset.
+	CampaignId    *int64 `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3,oneof" json:"campaign_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_ads_v1_ads_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetCampaignId() int64 {
+	if x != nil && x.CampaignId != nil {
+		return *x.CampaignId
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Impressions       int64                  `protobuf:"varint,1,opt,name=impressions,proto3" json:"impressions,omitempty"`
+	Clicks            int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	Cost              int64                  `protobuf:"varint,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	Events            map[string]int64       `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	EstimatedCtr      float64                `protobuf:"fixed64,5,opt,name=estimated_ctr,json=estimatedCtr,proto3" json:"estimated_ctr,omitempty"`
+	FirstQuartileRate float64                `protobuf:"fixed64,6,opt,name=first_quartile_rate,json=firstQuartileRate,proto3" json:"first_quartile_rate,omitempty"`
+	MidpointRate      float64                `protobuf:"fixed64,7,opt,name=midpoint_rate,json=midpointRate,proto3" json:"midpoint_rate,omitempty"`
+	ThirdQuartileRate float64                `protobuf:"fixed64,8,opt,name=third_quartile_rate,json=thirdQuartileRate,proto3" json:"third_quartile_rate,omitempty"`
+	CompletionRate    float64                `protobuf:"fixed64,9,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	// Estimated number of distinct users who saw an impression.
+	Reach int64 `protobuf:"varint,10,opt,name=reach,proto3" json:"reach,omitempty"`
+	// Impressions per reached user.
+	AvgFrequency  float64 `protobuf:"fixed64,11,opt,name=avg_frequency,json=avgFrequency,proto3" json:"avg_frequency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_ads_v1_ads_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ads_v1_ads_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_ads_v1_ads_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStatsResponse) GetImpressions() int64 {
+	if x != nil {
+		return x.Impressions
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCost() int64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetEvents() map[string]int64 {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetEstimatedCtr() float64 {
+	if x != nil {
+		return x.EstimatedCtr
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetFirstQuartileRate() float64 {
+	if x != nil {
+		return x.FirstQuartileRate
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMidpointRate() float64 {
+	if x != nil {
+		return x.MidpointRate
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetThirdQuartileRate() float64 {
+	if x != nil {
+		return x.ThirdQuartileRate
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetReach() int64 {
+	if x != nil {
+		return x.Reach
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetAvgFrequency() float64 {
+	if x != nil {
+		return x.AvgFrequency
+	}
+	return 0
+}
+
+var File_ads_v1_ads_proto protoreflect.FileDescriptor
+
+const file_ads_v1_ads_proto_rawDesc = "" +
+	"\n" +
+	"\x10ads/v1/ads.proto\x12\vmesa.ads.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x01\n" +
+	"\x10RequestAdRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x10\n" +
+	"\x03geo\x18\x03 \x01(\tR\x03geo\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x1c\n" +
+	"\tinterests\x18\x05 \x03(\tR\tinterests\x12\x1c\n" +
+	"\tplacement\x18\x06 \x01(\tR\tplacement\x12\x14\n" +
+	"\x05floor\x18\a \x01(\x03R\x05floor\"\x97\x03\n" +
+	"\x02Ad\x12\x1d\n" +
+	"\n" +
+	"serving_id\x18\x01 \x01(\tR\tservingId\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\x03R\n" +
+	"campaignId\x12\x1f\n" +
+	"\vcreative_id\x18\x03 \x01(\x03R\n" +
+	"creativeId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x1a\n" +
+	"\bduration\x18\x05 \x01(\x05R\bduration\x12\x1b\n" +
+	"\tvideo_url\x18\x06 \x01(\tR\bvideoUrl\x12\x1b\n" +
+	"\tclick_url\x18\a \x01(\tR\bclickUrl\x12%\n" +
+	"\x0eimpression_url\x18\b \x01(\tR\rimpressionUrl\x12F\n" +
+	"\rtracking_urls\x18\t \x03(\v2!.mesa.ads.v1.Ad.TrackingUrlsEntryR\ftrackingUrls\x12\x14\n" +
+	"\x05price\x18\n" +
+	" \x01(\x01R\x05price\x1a?\n" +
+	"\x11TrackingUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
+	"\x11RequestAdResponse\x12\x1f\n" +
+	"\x02ad\x18\x01 \x01(\v2\x0f.mesa.ads.v1.AdR\x02ad\",\n" +
+	"\x14RegisterClickRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"8\n" +
+	"\x15RegisterClickResponse\x12\x1f\n" +
+	"\vlanding_url\x18\x01 \x01(\tR\n" +
+	"landingUrl\"\xa3\x01\n" +
+	"\x0fGetStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12$\n" +
+	"\vcampaign_id\x18\x03 \x01(\x03H\x00R\n" +
+	"campaignId\x88\x01\x01B\x0e\n" +
+	"\f_campaign_id\"\xec\x03\n" +
+	"\x10GetStatsResponse\x12 \n" +
+	"\vimpressions\x18\x01 \x01(\x03R\vimpressions\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12\x12\n" +
+	"\x04cost\x18\x03 \x01(\x03R\x04cost\x12A\n" +
+	"\x06events\x18\x04 \x03(\v2).mesa.ads.v1.GetStatsResponse.EventsEntryR\x06events\x12#\n" +
+	"\restimated_ctr\x18\x05 \x01(\x01R\festimatedCtr\x12.\n" +
+	"\x13first_quartile_rate\x18\x06 \x01(\x01R\x11firstQuartileRate\x12#\n" +
+	"\rmidpoint_rate\x18\a \x01(\x01R\fmidpointRate\x12.\n" +
+	"\x13third_quartile_rate\x18\b \x01(\x01R\x11thirdQuartileRate\x12'\n" +
+	"\x0fcompletion_rate\x18\t \x01(\x01R\x0ecompletionRate\x12\x14\n" +
+	"\x05reach\x18\n" +
+	" \x01(\x03R\x05reach\x12#\n" +
+	"\ravg_frequency\x18\v \x01(\x01R\favgFrequency\x1a9\n" +
+	"\vEventsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x012\xf8\x01\n" +
+	"\tAdService\x12J\n" +
+	"\tRequestAd\x12\x1d.mesa.ads.v1.RequestAdRequest\x1a\x1e.mesa.ads.v1.RequestAdResponse\x12V\n" +
+	"\rRegisterClick\x12!.mesa.ads.v1.RegisterClickRequest\x1a\".mesa.ads.v1.RegisterClickResponse\x12G\n" +
+	"\bGetStats\x12\x1c.mesa.ads.v1.GetStatsRequest\x1a\x1d.mesa.ads.v1.GetStatsResponseB\x1aZ\x18mesa-ads/pkg/adsv1;adsv1b\x06proto3"
+
+var (
+	file_ads_v1_ads_proto_rawDescOnce sync.Once
+	file_ads_v1_ads_proto_rawDescData []byte
+)
+
+func file_ads_v1_ads_proto_rawDescGZIP() []byte {
+	file_ads_v1_ads_proto_rawDescOnce.Do(func() {
+		file_ads_v1_ads_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ads_v1_ads_proto_rawDesc), len(file_ads_v1_ads_proto_rawDesc)))
+	})
+	return file_ads_v1_ads_proto_rawDescData
+}
+
+var file_ads_v1_ads_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_ads_v1_ads_proto_goTypes = []any{
+	(*RequestAdRequest)(nil),      // 0: mesa.ads.v1.RequestAdRequest
+	(*Ad)(nil),                    // 1: mesa.ads.v1.Ad
+	(*RequestAdResponse)(nil),     // 2: mesa.ads.v1.RequestAdResponse
+	(*RegisterClickRequest)(nil),  // 3: mesa.ads.v1.RegisterClickRequest
+	(*RegisterClickResponse)(nil), // 4: mesa.ads.v1.RegisterClickResponse
+	(*GetStatsRequest)(nil),       // 5: mesa.ads.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),      // 6: mesa.ads.v1.GetStatsResponse
+	nil,                           // 7: mesa.ads.v1.Ad.TrackingUrlsEntry
+	nil,                           // 8: mesa.ads.v1.GetStatsResponse.EventsEntry
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_ads_v1_ads_proto_depIdxs = []int32{
+	7, // 0: mesa.ads.v1.Ad.tracking_urls:type_name -> mesa.ads.v1.Ad.TrackingUrlsEntry
+	1, // 1: mesa.ads.v1.RequestAdResponse.ad:type_name -> mesa.ads.v1.Ad
+	9, // 2: mesa.ads.v1.GetStatsRequest.from:type_name -> google.protobuf.Timestamp
+	9, // 3: mesa.ads.v1.GetStatsRequest.to:type_name -> google.protobuf.Timestamp
+	8, // 4: mesa.ads.v1.GetStatsResponse.events:type_name -> mesa.ads.v1.GetStatsResponse.EventsEntry
+	0, // 5: mesa.ads.v1.AdService.RequestAd:input_type -> mesa.ads.v1.RequestAdRequest
+	3, // 6: mesa.ads.v1.AdService.RegisterClick:input_type -> mesa.ads.v1.RegisterClickRequest
+	5, // 7: mesa.ads.v1.AdService.GetStats:input_type -> mesa.ads.v1.GetStatsRequest
+	2, // 8: mesa.ads.v1.AdService.RequestAd:output_type -> mesa.ads.v1.RequestAdResponse
+	4, // 9: mesa.ads.v1.AdService.RegisterClick:output_type -> mesa.ads.v1.RegisterClickResponse
+	6, // 10: mesa.ads.v1.AdService.GetStats:output_type -> mesa.ads.v1.GetStatsResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_ads_v1_ads_proto_init() }
+func file_ads_v1_ads_proto_init() {
+	if File_ads_v1_ads_proto != nil {
+		return
+	}
+	file_ads_v1_ads_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ads_v1_ads_proto_rawDesc), len(file_ads_v1_ads_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ads_v1_ads_proto_goTypes,
+		DependencyIndexes: file_ads_v1_ads_proto_depIdxs,
+		MessageInfos:      file_ads_v1_ads_proto_msgTypes,
+	}.Build()
+	File_ads_v1_ads_proto = out.File
+	file_ads_v1_ads_proto_goTypes = nil
+	file_ads_v1_ads_proto_depIdxs = nil
+}